@@ -0,0 +1,253 @@
+// Package cli implements the godi command line against a container registration.
+//
+// The godi binary cannot load user code at runtime, so the registration is a small main package
+// that passes its container options to Run:
+//
+//	func main() {
+//		os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr, app.ContainerOptions()...))
+//	}
+//
+// It can be run directly with go run, or through cmd/godi, which builds and runs it.
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/assurrussa/godi"
+)
+
+// Exit codes returned by Run.
+const (
+	ExitOK      = 0
+	ExitFailure = 1
+	ExitUsage   = 2
+)
+
+const usage = `usage: <registration> <command> [flags]
+
+commands:
+  graph      render a dependency graph (-format dot|json|mermaid|plantuml|html, -module name)
+  validate   check that every dependency is resolvable without running constructors
+  overrides  list replaced, shadowed and decorated providers (-json, -fail)
+  unused     list providers unreachable from consumers and runnables (-json); fails when not empty
+`
+
+var errUsage = errors.New("usage")
+
+// Run builds a container from opts and executes the command in args.
+// It returns ExitFailure on wiring problems so CI can gate on them, and ExitUsage on invalid arguments.
+func Run(args []string, stdout, stderr io.Writer, opts ...godi.ContainerOption) int {
+	if len(args) == 0 {
+		_, _ = fmt.Fprint(stderr, usage)
+		return ExitUsage
+	}
+
+	commands := map[string]func(*godi.Container, []string, io.Writer, io.Writer) (int, error){
+		"graph":     runGraph,
+		"validate":  runValidate,
+		"overrides": runOverrides,
+		"unused":    runUnused,
+	}
+	command, ok := commands[args[0]]
+	if !ok {
+		_, _ = fmt.Fprintf(stderr, "unknown command %q\n%s", args[0], usage)
+		return ExitUsage
+	}
+
+	cnt, err := godi.NewContainer(opts...)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "godi: %v\n", err)
+		return ExitFailure
+	}
+
+	code, err := command(cnt, args[1:], stdout, stderr)
+	if errors.Is(err, errUsage) {
+		return ExitUsage
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "godi %s: %v\n", args[0], err)
+		return ExitFailure
+	}
+	return code
+}
+
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	return flags
+}
+
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		return errUsage
+	}
+	if flags.NArg() > 0 {
+		_, _ = fmt.Fprintf(flags.Output(), "unexpected arguments: %s\n", strings.Join(flags.Args(), " "))
+		return errUsage
+	}
+	return nil
+}
+
+func runGraph(cnt *godi.Container, args []string, stdout, stderr io.Writer) (int, error) {
+	flags := newFlagSet("graph", stderr)
+	format := flags.String("format", "dot", "output format: dot, json, mermaid, plantuml or html")
+	module := flags.String("module", "", "render a module graph instead of the root graph")
+	if err := parseFlags(flags, args); err != nil {
+		return ExitUsage, err
+	}
+
+	graphs := cnt.GraphModules()
+	name := *module
+	if name == "" {
+		name = "root"
+	}
+	graph, ok := graphs[name]
+	if !ok {
+		return ExitFailure, fmt.Errorf("unknown module %q (available: %s)", *module, strings.Join(graphNames(graphs), ", "))
+	}
+
+	var (
+		out []byte
+		err error
+	)
+	switch *format {
+	case "dot":
+		out = []byte(graph.DOT())
+	case "json":
+		if *module == "" {
+			// The container document carries every scope, modules and overrides.
+			out, err = cnt.GraphJSON()
+		} else {
+			out, err = graph.JSON()
+		}
+	case "mermaid":
+		out = []byte(graph.Mermaid())
+	case "plantuml":
+		out = []byte(graph.PlantUML())
+	case "html":
+		out, err = graph.HTML()
+	default:
+		_, _ = fmt.Fprintf(stderr, "unknown format %q\n", *format)
+		return ExitUsage, errUsage
+	}
+	if err != nil {
+		return ExitFailure, err
+	}
+
+	_, _ = stdout.Write(out)
+	return ExitOK, nil
+}
+
+func runValidate(cnt *godi.Container, args []string, stdout, stderr io.Writer) (int, error) {
+	if err := parseFlags(newFlagSet("validate", stderr), args); err != nil {
+		return ExitUsage, err
+	}
+	if err := cnt.Validate(); err != nil {
+		return ExitFailure, err
+	}
+	_, _ = fmt.Fprintln(stdout, "ok")
+	return ExitOK, nil
+}
+
+func runOverrides(cnt *godi.Container, args []string, stdout, stderr io.Writer) (int, error) {
+	flags := newFlagSet("overrides", stderr)
+	asJSON := flags.Bool("json", false, "print overrides as JSON")
+	fail := flags.Bool("fail", false, "exit with a failure code when overrides are found")
+	if err := parseFlags(flags, args); err != nil {
+		return ExitUsage, err
+	}
+
+	overrides := cnt.Overrides()
+	if *asJSON {
+		if err := writeJSON(stdout, overrides); err != nil {
+			return ExitFailure, err
+		}
+	} else {
+		for _, o := range overrides {
+			_, _ = fmt.Fprintf(stdout, "%s %s %s: %s -> %s\n",
+				o.Scope, o.Kind, o.Key, providerLabel(o.Previous), providerLabel(o.Next))
+		}
+	}
+
+	if *fail && len(overrides) > 0 {
+		return ExitFailure, nil
+	}
+	return ExitOK, nil
+}
+
+func runUnused(cnt *godi.Container, args []string, stdout, stderr io.Writer) (int, error) {
+	flags := newFlagSet("unused", stderr)
+	asJSON := flags.Bool("json", false, "print the report as JSON")
+	if err := parseFlags(flags, args); err != nil {
+		return ExitUsage, err
+	}
+
+	// Roots come from WithUnusedCheck in the registration; runnables are always roots.
+	report, err := cnt.Unused(cnt.UnusedRoots()...)
+	if err != nil {
+		return ExitFailure, err
+	}
+
+	if *asJSON {
+		if err := writeJSON(stdout, report); err != nil {
+			return ExitFailure, err
+		}
+	} else {
+		for _, p := range report.Providers {
+			_, _ = fmt.Fprintf(stdout, "provider %s\n", nodeLabel(p))
+		}
+		for _, m := range report.Matchings {
+			_, _ = fmt.Fprintf(stdout, "matching %s\n", m)
+		}
+	}
+
+	if !report.Empty() {
+		return ExitFailure, nil
+	}
+	return ExitOK, nil
+}
+
+func writeJSON(w io.Writer, value any) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+func providerLabel(p godi.ProviderInfo) string {
+	label := strings.TrimSpace(p.Type + " " + p.Constructor)
+	if p.Module != "" {
+		label = p.Module + "/" + label
+	}
+	if p.File != "" {
+		label += fmt.Sprintf(" (%s:%d)", p.File, p.Line)
+	}
+	return label
+}
+
+func nodeLabel(n godi.ProviderNode) string {
+	return providerLabel(godi.ProviderInfo{
+		Module:      n.Module,
+		Constructor: n.Constructor,
+		Type:        n.Type,
+		File:        n.File,
+		Line:        n.Line,
+	})
+}
+
+func graphNames(graphs map[string]godi.Graph) []string {
+	names := make([]string, 0, len(graphs))
+	for name := range graphs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,144 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/assurrussa/godi"
+	"github.com/assurrussa/godi/cli"
+)
+
+type (
+	cliDB     struct{}
+	cliServer struct{}
+	cliUnused struct{}
+)
+
+func run(t *testing.T, args []string, opts ...godi.ContainerOption) (int, string, string) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	code := cli.Run(args, &stdout, &stderr, opts...)
+	return code, stdout.String(), stderr.String()
+}
+
+func appOptions() []godi.ContainerOption {
+	return []godi.ContainerOption{
+		godi.WithDependencies(godi.CollectDependencies(
+			godi.NewDependency(func() *cliDB { return &cliDB{} }),
+			godi.NewDependency(func(*cliDB) *cliServer { return &cliServer{} }),
+			godi.Replace(func() *cliDB { return &cliDB{} }),
+		)),
+		godi.WithModules(godi.NewModule("jobs", godi.NewSingleDependency(func() *cliUnused { return &cliUnused{} }))),
+		godi.WithUnusedCheck(func(*cliServer) {}),
+	}
+}
+
+func TestRunGraphFormats(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		args   []string
+		prefix string
+	}{
+		{args: []string{"graph"}, prefix: "digraph DI {"},
+		{args: []string{"graph", "-format", "mermaid"}, prefix: "flowchart LR"},
+		{args: []string{"graph", "-format", "plantuml"}, prefix: "@startuml"},
+		{args: []string{"graph", "-format", "html"}, prefix: "<!DOCTYPE html>"},
+		{args: []string{"graph", "-format", "json"}, prefix: "{"},
+		{args: []string{"graph", "-format", "json", "-module", "jobs"}, prefix: "{"},
+	}
+	for _, tt := range tests {
+		code, stdout, stderr := run(t, tt.args, appOptions()...)
+		if code != cli.ExitOK {
+			t.Fatalf("%v: exit %d, stderr: %s", tt.args, code, stderr)
+		}
+		if !strings.HasPrefix(stdout, tt.prefix) {
+			t.Fatalf("%v: expected prefix %q, got:\n%.200s", tt.args, tt.prefix, stdout)
+		}
+	}
+
+	_, stdout, _ := run(t, []string{"graph", "-format", "json"}, appOptions()...)
+	var doc struct {
+		Scopes map[string]json.RawMessage `json:"scopes"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &doc); err != nil {
+		t.Fatalf("decode graph JSON: %v", err)
+	}
+	if _, ok := doc.Scopes["jobs"]; !ok {
+		t.Fatalf("expected module scope in container JSON, got %v", doc.Scopes)
+	}
+}
+
+func TestRunValidate(t *testing.T) {
+	t.Parallel()
+
+	code, stdout, _ := run(t, []string{"validate"},
+		godi.WithDependencies(godi.NewSingleDependency(func() *cliDB { return &cliDB{} })))
+	if code != cli.ExitOK || strings.TrimSpace(stdout) != "ok" {
+		t.Fatalf("expected ok, got exit %d: %s", code, stdout)
+	}
+
+	code, _, stderr := run(t, []string{"validate"},
+		godi.WithDependencies(godi.NewSingleDependency(func(*cliDB) *cliServer { return &cliServer{} })))
+	if code != cli.ExitFailure || !strings.Contains(stderr, "godi validate:") {
+		t.Fatalf("expected validation failure, got exit %d: %s", code, stderr)
+	}
+}
+
+func TestRunOverrides(t *testing.T) {
+	t.Parallel()
+
+	code, stdout, _ := run(t, []string{"overrides"}, appOptions()...)
+	if code != cli.ExitOK || !strings.Contains(stdout, "root replace") {
+		t.Fatalf("expected a replacement, got exit %d:\n%s", code, stdout)
+	}
+
+	code, stdout, _ = run(t, []string{"overrides", "-json", "-fail"}, appOptions()...)
+	if code != cli.ExitFailure {
+		t.Fatalf("expected -fail to fail on overrides, got exit %d", code)
+	}
+	var overrides []godi.OverrideInfo
+	if err := json.Unmarshal([]byte(stdout), &overrides); err != nil || len(overrides) != 1 {
+		t.Fatalf("expected one override in JSON, got %v (%v)", overrides, err)
+	}
+}
+
+func TestRunUnused(t *testing.T) {
+	t.Parallel()
+
+	code, stdout, _ := run(t, []string{"unused"}, appOptions()...)
+	if code != cli.ExitFailure || !strings.Contains(stdout, "cliUnused") {
+		t.Fatalf("expected unused provider report, got exit %d:\n%s", code, stdout)
+	}
+
+	code, _, _ = run(t, []string{"unused"},
+		godi.WithDependencies(godi.NewSingleDependency(func() *cliDB { return &cliDB{} })),
+		godi.WithUnusedCheck(func(*cliDB) {}))
+	if code != cli.ExitOK {
+		t.Fatalf("expected no unused providers, got exit %d", code)
+	}
+}
+
+func TestRunUsageErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, args := range [][]string{
+		nil,
+		{"bogus"},
+		{"graph", "-format", "svg"},
+		{"validate", "extra"},
+		{"overrides", "-unknown"},
+	} {
+		if code, _, _ := run(t, args, appOptions()...); code != cli.ExitUsage {
+			t.Fatalf("%v: expected usage exit code, got %d", args, code)
+		}
+	}
+
+	code, _, stderr := run(t, []string{"graph", "-module", "missing"}, appOptions()...)
+	if code != cli.ExitFailure || !strings.Contains(stderr, "available: jobs, root") {
+		t.Fatalf("expected unknown module failure, got exit %d: %s", code, stderr)
+	}
+}
@@ -0,0 +1,79 @@
+// Command godi renders and lints the dependency graph of a registration package.
+//
+// A registration package is a main package that calls cli.Run with the application's container options
+// (see package github.com/assurrussa/godi/cli). godi builds it and runs it with the given command:
+//
+//	godi [-pkg ./cmd/godi-wiring] graph -format mermaid
+//	godi validate
+//	godi overrides -fail
+//	godi unused
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+)
+
+const defaultPackage = "./cmd/godi-wiring"
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("godi", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	pkg := flags.String("pkg", defaultPackage, "registration package that calls cli.Run")
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(stderr, "usage: godi [-pkg path] graph|validate|overrides|unused [flags]\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	dir, err := os.MkdirTemp("", "godi-")
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "godi: %v\n", err)
+		return 1
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	// Build first and run the binary directly, so its exit code reaches CI unchanged (go run reports 1).
+	binary := filepath.Join(dir, "registration")
+	build := exec.CommandContext(ctx, "go", "build", "-o", binary, *pkg) //nolint:gosec // the package is chosen by the caller
+	build.Stdout = stderr
+	build.Stderr = stderr
+	if err := build.Run(); err != nil {
+		_, _ = fmt.Fprintf(stderr, "godi: build %s: %v\n", *pkg, err)
+		return 1
+	}
+
+	cmd := exec.CommandContext(ctx, binary, flags.Args()...) //nolint:gosec // the binary was built above
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode()
+		}
+		_, _ = fmt.Fprintf(stderr, "godi: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,288 @@
+package config
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	durationType        = reflect.TypeFor[time.Duration]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// binder sets struct fields from the merged file tree, the environment and flags.
+type binder struct {
+	tree      map[string]any
+	lookupEnv func(string) (string, bool)
+	flags     map[string]string
+	missing   []Field
+	errs      []error
+}
+
+func (b *binder) bindStruct(value reflect.Value, path string, keys []string) {
+	t := value.Type()
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		key := fieldKey(sf)
+		if key == "-" {
+			continue
+		}
+		fieldPath := sf.Name
+		if path != "" {
+			fieldPath = path + "." + sf.Name
+		}
+		fieldKeys := append(append([]string{}, keys...), key)
+		field := value.Field(i)
+
+		if isNested(sf.Type) {
+			b.bindStruct(field, fieldPath, fieldKeys)
+			continue
+		}
+		b.bindField(field, sf, Field{
+			Path: fieldPath,
+			Key:  strings.Join(fieldKeys, "."),
+			Env:  sf.Tag.Get("env"),
+			Flag: sf.Tag.Get("flag"),
+		}, fieldKeys)
+	}
+}
+
+func (b *binder) bindField(field reflect.Value, sf reflect.StructField, info Field, keys []string) {
+	var err error
+	switch {
+	case info.Flag != "" && b.hasFlag(info.Flag):
+		err = setString(field, b.flags[info.Flag], true)
+	case info.Env != "" && b.hasEnv(info.Env):
+		value, _ := b.lookupEnv(info.Env)
+		err = setString(field, value, true)
+	default:
+		if value, ok := lookupTree(b.tree, keys); ok {
+			err = setTree(field, value)
+			break
+		}
+		if value, ok := sf.Tag.Lookup("default"); ok {
+			err = setString(field, value, true)
+			break
+		}
+		if sf.Tag.Get("required") == "true" {
+			b.missing = append(b.missing, info)
+		}
+	}
+	if err != nil {
+		b.errs = append(b.errs, fmt.Errorf("field %s: %w", info, err))
+	}
+}
+
+func (b *binder) hasFlag(name string) bool {
+	_, ok := b.flags[name]
+	return ok
+}
+
+func (b *binder) hasEnv(name string) bool {
+	if b.lookupEnv == nil {
+		return false
+	}
+	_, ok := b.lookupEnv(name)
+	return ok
+}
+
+// fieldKey is the config tag or the snake_case field name.
+func fieldKey(sf reflect.StructField) string {
+	if key, _, _ := strings.Cut(sf.Tag.Get("config"), ","); key != "" {
+		return key
+	}
+	var out strings.Builder
+	runes := []rune(sf.Name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 &&
+			(unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			out.WriteByte('_')
+		}
+		out.WriteRune(unicode.ToLower(r))
+	}
+	return out.String()
+}
+
+// isNested reports whether a field is a nested configuration struct rather than a value parsed from text.
+func isNested(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// declaredFlags collects the flag names of the struct; the value reports a bool field, which may be given
+// without a value.
+func declaredFlags(t reflect.Type) map[string]bool {
+	result := map[string]bool{}
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		switch {
+		case !sf.IsExported():
+		case isNested(sf.Type):
+			for name, isBool := range declaredFlags(sf.Type) {
+				result[name] = isBool
+			}
+		case sf.Tag.Get("flag") != "":
+			result[sf.Tag.Get("flag")] = sf.Type.Kind() == reflect.Bool
+		}
+	}
+	return result
+}
+
+// parseFlags reads the flags of args. A token starting with "-" is never taken as a value, so a flag the
+// struct does not declare cannot swallow the next flag; a declared flag without a value is an error.
+func parseFlags(args []string, declared map[string]bool) (map[string]string, error) {
+	flags := map[string]string{}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			break
+		}
+		if len(arg) < 2 || arg[0] != '-' {
+			continue
+		}
+		name := strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-")
+		if name, value, ok := strings.Cut(name, "="); ok {
+			flags[name] = value
+			continue
+		}
+		isBool, known := declared[name]
+		switch {
+		case isBool:
+			flags[name] = "true"
+		case i+1 < len(args) && !strings.HasPrefix(args[i+1], "-"):
+			flags[name] = args[i+1]
+			i++
+		case known:
+			return nil, fmt.Errorf("flag -%s needs a value, use -%s=value for values starting with \"-\"", name, name)
+		}
+	}
+	return flags, nil
+}
+
+// setTree sets a field from a file value, keeping the value's type: strings set text fields and durations,
+// json.Number values set numeric fields, bools set bool fields and lists set slices. An
+// encoding.TextUnmarshaler takes the text of any scalar.
+func setTree(field reflect.Value, value any) error {
+	switch v := value.(type) {
+	case []any:
+		if field.Kind() != reflect.Slice || field.Type().Implements(textUnmarshalerType) {
+			return fmt.Errorf("cannot use a list for %s", field.Type())
+		}
+		slice := reflect.MakeSlice(field.Type(), len(v), len(v))
+		for i, item := range v {
+			if err := setTree(slice.Index(i), item); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+		return nil
+	case map[string]any:
+		return fmt.Errorf("cannot use a table for %s", field.Type())
+	case nil:
+		return fmt.Errorf("cannot use null for %s", field.Type())
+	}
+
+	if field.CanAddr() && field.Addr().Type().Implements(textUnmarshalerType) {
+		return setString(field, fmt.Sprint(value), false)
+	}
+	kind := field.Kind()
+	switch v := value.(type) {
+	case string:
+		if kind != reflect.String && field.Type() != durationType {
+			return fmt.Errorf("cannot use string %q for %s", v, field.Type())
+		}
+	case bool:
+		if kind != reflect.Bool {
+			return fmt.Errorf("cannot use bool %t for %s", v, field.Type())
+		}
+	case json.Number:
+		if !isNumeric(kind) || field.Type() == durationType {
+			return fmt.Errorf("cannot use number %s for %s", v, field.Type())
+		}
+	default:
+		return fmt.Errorf("unsupported value %v for %s", v, field.Type())
+	}
+	return setString(field, fmt.Sprint(value), false)
+}
+
+func isNumeric(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// setString parses text into a field. Slices take comma-separated items when split is set (env and flags).
+func setString(field reflect.Value, text string, split bool) error {
+	if field.CanAddr() && field.Addr().Type().Implements(textUnmarshalerType) {
+		unmarshaler, _ := field.Addr().Interface().(encoding.TextUnmarshaler)
+		return unmarshaler.UnmarshalText([]byte(text))
+	}
+	if field.Type() == durationType {
+		d, err := time.ParseDuration(text)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(text)
+	case reflect.Bool:
+		v, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+		field.SetBool(v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(text, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := strconv.ParseUint(text, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(v)
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(text, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(v)
+	case reflect.Slice:
+		if !split {
+			return fmt.Errorf("cannot use a scalar for %s", field.Type())
+		}
+		items := []string{}
+		if text != "" {
+			items = strings.Split(text, ",")
+		}
+		slice := reflect.MakeSlice(field.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := setString(slice.Index(i), strings.TrimSpace(item), false); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+// Package config provides configuration structs as godi dependencies.
+//
+// A configuration struct describes its sources with field tags:
+//
+//	type DBConfig struct {
+//		URL      string        `env:"DB_URL" flag:"db-url" required:"true"`
+//		Timeout  time.Duration `config:"timeout" default:"5s"`
+//		Replicas []string      `env:"DB_REPLICAS"`
+//	}
+//
+// Every field is looked up, from the highest precedence down, in command-line flags (flag tag), environment
+// variables (env tag), configuration files (config tag, or the snake_case field name) and the default tag.
+// Later files override earlier ones. Nested structs are nested tables in files; their env and flag names are
+// taken as written. A field with required:"true" that no source sets is reported by Load, by the constructor
+// of Provide and, as a wiring error, by godi's Validate.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/assurrussa/godi"
+)
+
+// Option adds a source or changes how a configuration is loaded.
+type Option func(*loader)
+
+type loader struct {
+	files      []string
+	lookupEnv  func(string) (string, bool)
+	args       []string
+	flags      bool
+	dependency []godi.DependencyOption
+}
+
+// FromFile reads a JSON (.json), YAML (.yaml, .yml) or TOML (.toml) file. A missing file is an error, and so is
+// YAML or TOML syntax outside the supported subset. File values keep their type: a quoted "8080" does not set
+// an int field.
+func FromFile(path string) Option {
+	return func(l *loader) { l.files = append(l.files, path) }
+}
+
+// FromEnv reads environment variables.
+func FromEnv() Option {
+	return FromEnvLookup(os.LookupEnv)
+}
+
+// FromEnvLookup reads environment variables through lookup, such as a map in tests.
+func FromEnvLookup(lookup func(key string) (string, bool)) Option {
+	return func(l *loader) { l.lookupEnv = lookup }
+}
+
+// FromFlags reads flags from args (usually os.Args[1:]) in the -name=value, -name value, --name=value and
+// --name value forms; a bool flag without a value is true. Flags not declared by the struct are skipped, so
+// several configurations can share the same arguments. A token starting with "-" is never a value: write
+// -offset=-5 for such values. Parsing stops at "--".
+func FromFlags(args []string) Option {
+	return func(l *loader) {
+		l.args = args
+		l.flags = true
+	}
+}
+
+// WithDependencyOptions passes options such as godi.WithName to the dependency created by Provide.
+func WithDependencyOptions(opts ...godi.DependencyOption) Option {
+	return func(l *loader) { l.dependency = append(l.dependency, opts...) }
+}
+
+// Provide returns a dependency that provides T loaded from the given sources. The constructor fails when
+// loading fails, and a godi.WithCheck check reports the same problems from Validate without building the graph.
+// The sources are read once: the check validates the value the constructor later returns.
+func Provide[T any](opts ...Option) godi.Dependency {
+	var l loader
+	for _, opt := range opts {
+		opt(&l)
+	}
+	load := sync.OnceValues(func() (T, error) { return Load[T](opts...) })
+	check := func() error {
+		_, err := load()
+		return err
+	}
+	deps := append([]godi.DependencyOption{godi.WithCheck(check)}, l.dependency...)
+	// The closure keeps Provide as the reported constructor instead of sync.OnceValues.
+	return godi.NewDependency(func() (T, error) { return load() }, deps...)
+}
+
+// Load reads T from the given sources.
+func Load[T any](opts ...Option) (T, error) {
+	var cfg T
+	l := loader{}
+	for _, opt := range opts {
+		opt(&l)
+	}
+
+	value := reflect.ValueOf(&cfg).Elem()
+	if value.Kind() != reflect.Struct {
+		return cfg, fmt.Errorf("config: %s is not a struct", value.Type())
+	}
+
+	tree := map[string]any{}
+	for _, path := range l.files {
+		file, err := readFile(path)
+		if err != nil {
+			return cfg, fmt.Errorf("config %s: %w", value.Type(), err)
+		}
+		mergeTree(tree, file)
+	}
+
+	var flags map[string]string
+	if l.flags {
+		var err error
+		if flags, err = parseFlags(l.args, declaredFlags(value.Type())); err != nil {
+			return cfg, fmt.Errorf("config %s: %w", value.Type(), err)
+		}
+	}
+
+	b := binder{tree: tree, lookupEnv: l.lookupEnv, flags: flags}
+	b.bindStruct(value, "", nil)
+	if len(b.missing) > 0 {
+		b.errs = append(b.errs, &MissingFieldsError{Type: value.Type(), Fields: b.missing})
+	}
+	if len(b.errs) > 0 {
+		return cfg, fmt.Errorf("config %s: %w", value.Type(), errors.Join(b.errs...))
+	}
+	return cfg, nil
+}
+
+// Field names the sources of a configuration field.
+type Field struct {
+	// Path is the Go field path, such as "DB.URL".
+	Path string
+	// Key is the dotted file key, such as "db.url".
+	Key  string
+	Env  string
+	Flag string
+}
+
+func (f Field) String() string {
+	sources := []string{"key " + f.Key}
+	if f.Env != "" {
+		sources = append(sources, "env "+f.Env)
+	}
+	if f.Flag != "" {
+		sources = append(sources, "flag -"+f.Flag)
+	}
+	return f.Path + " (" + strings.Join(sources, ", ") + ")"
+}
+
+// MissingFieldsError lists required fields that no source sets.
+type MissingFieldsError struct {
+	Type   reflect.Type
+	Fields []Field
+}
+
+func (e *MissingFieldsError) Error() string {
+	fields := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		fields = append(fields, field.String())
+	}
+	return "missing required fields: " + strings.Join(fields, ", ")
+}
@@ -0,0 +1,307 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/dig"
+
+	"github.com/assurrussa/godi"
+	"github.com/assurrussa/godi/config"
+)
+
+type dbConfig struct {
+	URL     string        `env:"DB_URL" flag:"db-url" required:"true"`
+	Timeout time.Duration `default:"5s"`
+}
+
+type appConfig struct {
+	Name    string   `env:"APP_NAME" required:"true"`
+	Port    int      `env:"PORT" flag:"port" default:"80"`
+	Debug   bool     `flag:"debug"`
+	Tags    []string `env:"TAGS"`
+	DB      dbConfig
+	Ignored string `config:"-"`
+}
+
+func env(values map[string]string) config.Option {
+	return config.FromEnvLookup(func(key string) (string, bool) {
+		value, ok := values[key]
+		return value, ok
+	})
+}
+
+func TestLoadFileFormats(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range []string{"json", "yaml", "toml"} {
+		cfg, err := config.Load[appConfig](config.FromFile("testdata/app." + format))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", format, err)
+		}
+		if cfg.Name != format+"-app" || cfg.Port < 8080 || !cfg.Debug || cfg.DB.URL != "postgres://"+format ||
+			cfg.DB.Timeout != 3*time.Second {
+			t.Fatalf("%s: unexpected config: %+v", format, cfg)
+		}
+		if len(cfg.Tags) != 2 || cfg.Tags[0] != "a" {
+			t.Fatalf("%s: unexpected tags: %q", format, cfg.Tags)
+		}
+	}
+
+	cfg, err := config.Load[appConfig](config.FromFile("testdata/app.yaml"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Tags[1] != "b # not a comment" {
+		t.Fatalf("quoted hash must not start a comment: %q", cfg.Tags[1])
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := config.Load[appConfig](
+		config.FromFlags([]string{"serve", "-port", "7000", "--debug", "-unknown=1", "--", "-db-url=ignored"}),
+		env(map[string]string{"PORT": "6000", "DB_URL": "postgres://env", "TAGS": "x, y"}),
+		config.FromFile("testdata/app.toml"),
+		config.FromFile("testdata/override.yaml"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Port != 7000 {
+		t.Fatalf("flags must win over env and files, got port %d", cfg.Port)
+	}
+	if cfg.DB.URL != "postgres://env" {
+		t.Fatalf("env must win over files, got %q", cfg.DB.URL)
+	}
+	if cfg.DB.Timeout != 10*time.Second || cfg.Name != "toml-app" {
+		t.Fatalf("later files must override earlier ones key by key: %+v", cfg)
+	}
+	if !cfg.Debug || len(cfg.Tags) != 2 || cfg.Tags[1] != "y" {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+
+	defaults, err := config.Load[appConfig](env(map[string]string{"DB_URL": "u", "APP_NAME": "n"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if defaults.Port != 80 || defaults.DB.Timeout != 5*time.Second {
+		t.Fatalf("defaults must apply when no source sets a field: %+v", defaults)
+	}
+}
+
+type serverConfig struct {
+	Port int `flag:"port" default:"80"`
+}
+
+func TestLoadFlagsSharedWithOtherConfigs(t *testing.T) {
+	t.Parallel()
+
+	// -verbose belongs to another configuration and must not take -port as its value.
+	cfg, err := config.Load[serverConfig](config.FromFlags([]string{"-verbose", "-port", "8080"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Port != 8080 {
+		t.Fatalf("expected -port to be read after an undeclared flag, got %+v", cfg)
+	}
+
+	_, err = config.Load[serverConfig](config.FromFlags([]string{"-port", "-5"}))
+	if err == nil || !strings.Contains(err.Error(), "flag -port needs a value") {
+		t.Fatalf("expected a missing value error, got %v", err)
+	}
+	cfg, err = config.Load[serverConfig](config.FromFlags([]string{"-port=-5"}))
+	if err != nil || cfg.Port != -5 {
+		t.Fatalf("expected -port=-5 to be read, got %+v, %v", cfg, err)
+	}
+}
+
+func TestLoadReportsMissingFields(t *testing.T) {
+	t.Parallel()
+
+	_, err := config.Load[appConfig]()
+	var missing *config.MissingFieldsError
+	if !errors.As(err, &missing) || len(missing.Fields) != 2 {
+		t.Fatalf("expected 2 missing fields, got %v", err)
+	}
+	if missing.Fields[1].String() != "DB.URL (key db.url, env DB_URL, flag -db-url)" {
+		t.Fatalf("unexpected field: %s", missing.Fields[1])
+	}
+}
+
+func TestProvideReportsMissingFieldsFromValidate(t *testing.T) {
+	t.Parallel()
+
+	cnt, err := godi.NewContainer(godi.WithDependencies(godi.CollectDependencies(
+		config.Provide[dbConfig](env(map[string]string{})),
+		godi.NewDependency(func(cfg dbConfig) string { return cfg.URL }),
+	)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = cnt.Validate()
+	var wiringErr *godi.WiringError
+	if !errors.As(err, &wiringErr) || wiringErr.Kind != godi.WiringCheckFailed {
+		t.Fatalf("expected a check failure, got %v", err)
+	}
+	var missing *config.MissingFieldsError
+	if !errors.As(err, &missing) || !strings.Contains(err.Error(), "config_test.dbConfig") {
+		t.Fatalf("expected missing fields in %v", err)
+	}
+
+	if err := cnt.Invoke(func(string) {}); err == nil || !errors.As(err, &missing) {
+		t.Fatalf("expected the constructor to fail with missing fields, got %v", err)
+	}
+}
+
+func TestProvideWithDependencyOptions(t *testing.T) {
+	t.Parallel()
+
+	cnt, err := godi.NewContainer(godi.WithDependencies(godi.CollectDependencies(
+		config.Provide[dbConfig](
+			env(map[string]string{"DB_URL": "postgres://primary"}),
+			config.WithDependencyOptions(godi.WithName("primary")),
+		),
+	)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cnt.Validate(); err != nil {
+		t.Fatalf("unexpected validate error: %v", err)
+	}
+	var got dbConfig
+	if err := cnt.Invoke(func(in struct {
+		dig.In
+		DB dbConfig `name:"primary"`
+	},
+	) {
+		got = in.DB
+	}); err != nil {
+		t.Fatalf("unexpected invoke error: %v", err)
+	}
+	if got.URL != "postgres://primary" {
+		t.Fatalf("unexpected config: %+v", got)
+	}
+}
+
+func TestLoadRejectsInvalidFiles(t *testing.T) {
+	t.Parallel()
+
+	if _, err := config.Load[appConfig](config.FromFile("testdata/missing.yaml")); err == nil {
+		t.Fatalf("expected error for a missing file")
+	}
+	if _, err := config.Load[appConfig](config.FromFile("testdata/app.ini")); err == nil {
+		t.Fatalf("expected error for an unsupported format")
+	}
+	_, err := config.Load[appConfig](env(map[string]string{"PORT": "eighty"}))
+	if err == nil || !strings.Contains(err.Error(), "Port") {
+		t.Fatalf("expected a parse error naming the field, got %v", err)
+	}
+}
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestLoadRejectsUnsupportedSyntax(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		file    string
+		content string
+		want    string
+	}{
+		{name: "anchor", file: "a.yaml", content: "name: &name app\n", want: "anchors and aliases"},
+		{name: "alias", file: "a.yaml", content: "tags:\n  - *name\n", want: "anchors and aliases"},
+		{name: "literal block", file: "a.yaml", content: "name: |\n  app\n", want: "block scalars"},
+		{name: "folded block", file: "a.yaml", content: "name: >-\n  app\n", want: "block scalars"},
+		{name: "flow mapping", file: "a.yaml", content: "db: {url: x}\n", want: "flow mappings"},
+		{name: "mapping item", file: "a.yaml", content: "tags:\n  - name: a\n", want: "mappings inside sequences"},
+		{name: "tag", file: "a.yaml", content: "port: !!int 80\n", want: "tags are not supported"},
+		{name: "documents", file: "a.yaml", content: "---\nname: a\n---\nname: b\n", want: "multiple documents"},
+		{name: "inline table", file: "a.toml", content: "db = {url = \"x\"}\n", want: "inline tables"},
+		{name: "table array", file: "a.toml", content: "[[db]]\nurl = \"x\"\n", want: "arrays of tables"},
+		{name: "date", file: "a.toml", content: "since = 1979-05-27\n", want: "dates"},
+		{name: "multi-line string", file: "a.toml", content: "name = \"\"\"\napp\"\"\"\n", want: "multi-line strings"},
+		{name: "multi-line array", file: "a.toml", content: "tags = [\n  \"a\",\n]\n", want: "multi-line"},
+		{name: "table in array", file: "a.toml", content: "tags = [{a = 1}]\n", want: "inline tables"},
+	}
+	for _, tc := range cases {
+		_, err := config.Load[appConfig](config.FromFile(writeConfig(t, tc.file, tc.content)))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%s: expected %q error, got %v", tc.name, tc.want, err)
+		}
+	}
+}
+
+func TestLoadKeepsFileValueTypes(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		file    string
+		content string
+		want    string
+	}{
+		{file: "a.yaml", content: "port: \"8080\"\n", want: `cannot use string "8080" for int`},
+		{file: "a.toml", content: "port = '8080'\n", want: `cannot use string "8080" for int`},
+		{file: "a.json", content: `{"port": "8080"}`, want: `cannot use string "8080" for int`},
+		{file: "a.yaml", content: "name: 42\n", want: "cannot use number 42 for string"},
+		{file: "a.json", content: `{"debug": "true"}`, want: `cannot use string "true" for bool`},
+		{file: "a.toml", content: "port = true\n", want: "cannot use bool true for int"},
+		{file: "a.yaml", content: "db:\n  timeout: 5\n", want: "cannot use number 5 for time.Duration"},
+		{file: "a.yaml", content: "tags: [a, 1]\n", want: "cannot use number 1 for string"},
+	}
+	for _, tc := range cases {
+		_, err := config.Load[appConfig](config.FromFile(writeConfig(t, tc.file, tc.content)))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%s %q: expected %q, got %v", tc.file, tc.content, tc.want, err)
+		}
+	}
+
+	path := writeConfig(t, "a.toml", "name = \"007\"\nport = 0x1F90\n[db]\nurl = '1.5'\n")
+	cfg, err := config.Load[appConfig](config.FromFile(path))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Name != "007" || cfg.Port != 8080 || cfg.DB.URL != "1.5" {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+}
+
+func TestProvideChecksTheLoadedValue(t *testing.T) {
+	t.Parallel()
+
+	values := map[string]string{"DB_URL": "postgres://validated"}
+	cnt, err := godi.NewContainer(godi.WithDependencies(godi.CollectDependencies(
+		config.Provide[dbConfig](env(values)),
+	)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cnt.Validate(); err != nil {
+		t.Fatalf("unexpected validate error: %v", err)
+	}
+
+	// The environment changes after Validate; the constructor returns the value that was checked.
+	delete(values, "DB_URL")
+	var got dbConfig
+	if err := cnt.Invoke(func(cfg dbConfig) { got = cfg }); err != nil {
+		t.Fatalf("unexpected invoke error: %v", err)
+	}
+	if got.URL != "postgres://validated" {
+		t.Fatalf("unexpected config: %+v", got)
+	}
+}
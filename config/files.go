@@ -0,0 +1,559 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// readFile parses a configuration file into a tree of map[string]any tables, []any lists and string, bool and
+// json.Number scalars.
+func readFile(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // Configuration paths are chosen by the application.
+	if err != nil {
+		return nil, err
+	}
+
+	var tree map[string]any
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		tree, err = parseJSON(data)
+	case ".yaml", ".yml":
+		tree, err = parseYAML(data)
+	case ".toml":
+		tree, err = parseTOML(data)
+	default:
+		return nil, fmt.Errorf("%s: unsupported config format %q", path, ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return tree, nil
+}
+
+// mergeTree copies src into dst, merging tables key by key.
+func mergeTree(dst, src map[string]any) {
+	for key, value := range src {
+		srcTable, srcOK := value.(map[string]any)
+		dstTable, dstOK := dst[key].(map[string]any)
+		if srcOK && dstOK {
+			mergeTree(dstTable, srcTable)
+			continue
+		}
+		dst[key] = value
+	}
+}
+
+func lookupTree(tree map[string]any, keys []string) (any, bool) {
+	var value any = tree
+	for _, key := range keys {
+		table, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = table[key]; !ok {
+			return nil, false
+		}
+	}
+	// A null YAML value leaves the field to the default.
+	return value, value != nil
+}
+
+func parseJSON(data []byte) (map[string]any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	tree := map[string]any{}
+	if err := decoder.Decode(&tree); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// parseYAML reads the block-style YAML subset used by configuration files: nested mappings by indentation,
+// "- item" sequences of scalars, [a, b] flow sequences, quoted and plain scalars and comments. Plain scalars
+// keep their YAML type: true and false are bools, numbers are json.Number and null or ~ is null. Anything
+// outside the subset, such as anchors, tags, block scalars, flow mappings, mappings inside sequences and
+// multiple documents, is an error.
+func parseYAML(data []byte) (map[string]any, error) {
+	type frame struct {
+		indent int
+		table  map[string]any
+	}
+	root := map[string]any{}
+	stack := []frame{{indent: 0, table: root}}
+
+	// open is the last "key:" without a value; the following lines make it a table or a list.
+	var open struct {
+		table  map[string]any
+		key    string
+		indent int
+		list   bool
+	}
+	hasOpen := false
+	content := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		raw := strings.TrimRight(stripComment(scanner.Text()), " \t")
+		line := strings.TrimLeft(raw, " ")
+		if line == "" {
+			continue
+		}
+		if err := checkYAMLLine(line, content); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		if line == "---" {
+			continue
+		}
+		content = true
+		indent := len(raw) - len(line)
+
+		if item, ok := strings.CutPrefix(line, "-"); ok && (item == "" || item[0] == ' ') {
+			if !hasOpen || indent < open.indent {
+				return nil, fmt.Errorf("line %d: list item without a key", lineNo)
+			}
+			if !open.list {
+				open.table[open.key] = []any{}
+				open.list = true
+			}
+			value, err := parseYAMLItem(strings.TrimSpace(item))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			items, _ := open.table[open.key].([]any)
+			open.table[open.key] = append(items, value)
+			continue
+		}
+
+		if hasOpen {
+			hasOpen = false
+			switch {
+			case open.list:
+			case indent > open.indent:
+				table := map[string]any{}
+				open.table[open.key] = table
+				stack = append(stack, frame{indent: indent, table: table})
+			default:
+				open.table[open.key] = nil
+			}
+		}
+		for len(stack) > 1 && indent < stack[len(stack)-1].indent {
+			stack = stack[:len(stack)-1]
+		}
+		if indent != stack[len(stack)-1].indent {
+			return nil, fmt.Errorf("line %d: unexpected indentation", lineNo)
+		}
+		table := stack[len(stack)-1].table
+
+		key, value, err := splitYAMLEntry(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		if _, exists := table[key]; exists {
+			return nil, fmt.Errorf("line %d: duplicate key %q", lineNo, key)
+		}
+
+		if value == "" {
+			open.table, open.key, open.indent, open.list = table, key, indent, false
+			hasOpen = true
+			continue
+		}
+		parsed, err := parseYAMLValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		table[key] = parsed
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if hasOpen && !open.list {
+		open.table[open.key] = nil
+	}
+	return root, nil
+}
+
+// checkYAMLLine rejects document markers, directives and complex keys; content reports whether a previous
+// line had content, after which "---" starts a second document.
+func checkYAMLLine(line string, content bool) error {
+	switch {
+	case strings.HasPrefix(line, "\t"):
+		return errors.New("tabs are not allowed for indentation")
+	case line == "---" && content, line == "...":
+		return errors.New("multiple documents are not supported")
+	case strings.HasPrefix(line, "%"):
+		return errors.New("directives are not supported")
+	case line == "?" || strings.HasPrefix(line, "? "):
+		return errors.New("complex keys are not supported")
+	}
+	return nil
+}
+
+// splitYAMLEntry splits a "key: value" line; the value is empty for "key:".
+func splitYAMLEntry(line string) (string, string, error) {
+	var key, value string
+	if strings.HasPrefix(line, `"`) || strings.HasPrefix(line, "'") {
+		end := closingQuote(line)
+		if end < 0 || !strings.HasPrefix(line[end+1:], ":") {
+			return "", "", errors.New(`expected "key: value"`)
+		}
+		text, err := parseYAMLQuoted(line[:end+1])
+		if err != nil {
+			return "", "", err
+		}
+		key, value = text, line[end+2:]
+	} else {
+		var ok bool
+		key, value, ok = strings.Cut(line, ":")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return "", "", errors.New(`expected "key: value"`)
+		}
+		if strings.ContainsAny(key[:1], yamlIndicators) {
+			return "", "", fmt.Errorf("unsupported key %s", key)
+		}
+	}
+	if value != "" && value[0] != ' ' {
+		return "", "", errors.New(`expected "key: value"`)
+	}
+	return key, strings.TrimSpace(value), nil
+}
+
+// yamlIndicators start YAML constructs that the parser does not support when they begin a plain scalar or key.
+const yamlIndicators = "&*!|>{}[]@`%?,#"
+
+// parseYAMLItem reads a "- item" sequence entry, which must be a scalar or a flow sequence.
+func parseYAMLItem(item string) (any, error) {
+	if !strings.HasPrefix(item, `"`) && !strings.HasPrefix(item, "'") && isYAMLMapping(item) {
+		return nil, errors.New("mappings inside sequences are not supported")
+	}
+	return parseYAMLValue(item)
+}
+
+// parseYAMLValue reads a quoted or plain scalar or a [a, b] flow sequence of them.
+func parseYAMLValue(value string) (any, error) {
+	switch {
+	case value == "":
+		return nil, nil //nolint:nilnil // An empty scalar is null.
+	case strings.HasPrefix(value, "["):
+		if !strings.HasSuffix(value, "]") {
+			return nil, fmt.Errorf("unterminated list %s", value)
+		}
+		items := []any{}
+		for _, item := range splitList(value[1 : len(value)-1]) {
+			parsed, err := parseYAMLItem(item)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, parsed)
+		}
+		return items, nil
+	case strings.HasPrefix(value, `"`), strings.HasPrefix(value, "'"):
+		return parseYAMLQuoted(value)
+	case strings.HasPrefix(value, "&"), strings.HasPrefix(value, "*"):
+		return nil, fmt.Errorf("anchors and aliases are not supported: %s", value)
+	case strings.HasPrefix(value, "|"), strings.HasPrefix(value, ">"):
+		return nil, fmt.Errorf("block scalars are not supported: %s", value)
+	case strings.HasPrefix(value, "{"):
+		return nil, fmt.Errorf("flow mappings are not supported: %s", value)
+	case value == "-", strings.HasPrefix(value, "- "):
+		return nil, fmt.Errorf("nested block sequences are not supported: %s", value)
+	case strings.HasPrefix(value, "!"):
+		return nil, fmt.Errorf("tags are not supported: %s", value)
+	case strings.ContainsAny(value[:1], yamlIndicators):
+		return nil, fmt.Errorf("unsupported value %s", value)
+	case isYAMLMapping(value):
+		return nil, fmt.Errorf("nested mappings must start on a new line: %s", value)
+	}
+	return yamlPlain(value), nil
+}
+
+func parseYAMLQuoted(value string) (string, error) {
+	if strings.HasPrefix(value, `"`) {
+		text, err := strconv.Unquote(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid string %s", value)
+		}
+		return text, nil
+	}
+	if len(value) < 2 || closingQuote(value) != len(value)-1 {
+		return "", fmt.Errorf("invalid string %s", value)
+	}
+	return strings.ReplaceAll(value[1:len(value)-1], "''", "'"), nil
+}
+
+// closingQuote returns the index of the quote that closes the string s starts with, or -1.
+func closingQuote(s string) int {
+	quote := s[0]
+	for i := 1; i < len(s); i++ {
+		switch {
+		case quote == '"' && s[i] == '\\':
+			i++
+		case s[i] != quote:
+		case quote == '\'' && i+1 < len(s) && s[i+1] == '\'':
+			i++
+		default:
+			return i
+		}
+	}
+	return -1
+}
+
+// isYAMLMapping reports whether a plain scalar is really a "key: value" pair.
+func isYAMLMapping(value string) bool {
+	return strings.Contains(value, ": ") || strings.HasSuffix(value, ":")
+}
+
+var (
+	yamlInt   = regexp.MustCompile(`^[-+]?[0-9]+$`)
+	yamlFloat = regexp.MustCompile(`^[-+]?(\.[0-9]+|[0-9]+(\.[0-9]*)?)([eE][-+]?[0-9]+)?$`)
+	yamlRadix = regexp.MustCompile(`^0(o[0-7]+|x[0-9a-fA-F]+)$`)
+)
+
+// yamlPlain resolves a plain scalar with the YAML 1.2 core schema: null, bools, numbers and otherwise text.
+func yamlPlain(value string) any {
+	switch value {
+	case "~", "null", "Null", "NULL":
+		return nil
+	case "true", "True", "TRUE":
+		return true
+	case "false", "False", "FALSE":
+		return false
+	case ".inf", ".Inf", ".INF", "+.inf", "+.Inf", "+.INF":
+		return json.Number("+Inf")
+	case "-.inf", "-.Inf", "-.INF":
+		return json.Number("-Inf")
+	case ".nan", ".NaN", ".NAN":
+		return json.Number("NaN")
+	}
+	switch {
+	case yamlInt.MatchString(value), yamlFloat.MatchString(value):
+		return json.Number(strings.TrimPrefix(value, "+"))
+	case yamlRadix.MatchString(value):
+		if number, err := strconv.ParseUint(value, 0, 64); err == nil {
+			return json.Number(strconv.FormatUint(number, 10))
+		}
+	}
+	return value
+}
+
+// parseTOML reads the TOML subset used by configuration files: [table] headers, dotted keys, strings,
+// numbers, booleans, single-line arrays and comments. Numbers are json.Number. Arrays of tables, inline
+// tables, multi-line strings and arrays and dates are errors.
+func parseTOML(data []byte) (map[string]any, error) {
+	root := map[string]any{}
+	current := root
+	headers := map[string]bool{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if strings.HasPrefix(line, "[[") {
+				return nil, fmt.Errorf("line %d: arrays of tables are not supported", lineNo)
+			}
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: unsupported table header %s", lineNo, line)
+			}
+			path := splitKey(line[1 : len(line)-1])
+			name := strings.Join(path, ".")
+			if headers[name] {
+				return nil, fmt.Errorf("line %d: duplicate table [%s]", lineNo, name)
+			}
+			headers[name] = true
+			table, err := ensureTable(root, path)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			current = table
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("line %d: expected \"key = value\"", lineNo)
+		}
+		path := splitKey(key)
+		table, err := ensureTable(current, path[:len(path)-1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		name := path[len(path)-1]
+		if _, exists := table[name]; exists {
+			return nil, fmt.Errorf("line %d: duplicate key %q", lineNo, name)
+		}
+		parsed, err := parseTOMLValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		table[name] = parsed
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+var (
+	tomlInt   = regexp.MustCompile(`^[-+]?(0|[1-9](_?[0-9])*)$`)
+	tomlRadix = regexp.MustCompile(`^0(x[0-9a-fA-F](_?[0-9a-fA-F])*|o[0-7](_?[0-7])*|b[01](_?[01])*)$`)
+	tomlFloat = regexp.MustCompile(
+		`^[-+]?(0|[1-9](_?[0-9])*)(\.[0-9](_?[0-9])*)?([eE][-+]?[0-9](_?[0-9])*)?$|^[-+]?(inf|nan)$`)
+	tomlDate = regexp.MustCompile(`^[0-9]{4}-[0-9]{2}-[0-9]{2}|^[0-9]{2}:[0-9]{2}:[0-9]{2}`)
+)
+
+func parseTOMLValue(value string) (any, error) {
+	switch {
+	case value == "":
+		return nil, errors.New("missing value")
+	case strings.HasPrefix(value, "{"):
+		return nil, errors.New("inline tables are not supported")
+	case strings.HasPrefix(value, `"""`), strings.HasPrefix(value, "'''"):
+		return nil, errors.New("multi-line strings are not supported")
+	case strings.HasPrefix(value, "["):
+		if !strings.HasSuffix(value, "]") {
+			return nil, fmt.Errorf("multi-line or unterminated array %s", value)
+		}
+		items := []any{}
+		for _, item := range splitList(value[1 : len(value)-1]) {
+			parsed, err := parseTOMLValue(item)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, parsed)
+		}
+		return items, nil
+	case strings.HasPrefix(value, `"`):
+		text, err := strconv.Unquote(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid string %s", value)
+		}
+		return text, nil
+	case strings.HasPrefix(value, "'"):
+		if len(value) < 2 || strings.IndexByte(value[1:], '\'') != len(value)-2 {
+			return nil, fmt.Errorf("invalid string %s", value)
+		}
+		return value[1 : len(value)-1], nil
+	case value == "true":
+		return true, nil
+	case value == "false":
+		return false, nil
+	case tomlDate.MatchString(value):
+		return nil, fmt.Errorf("dates are not supported: %s", value)
+	}
+	return tomlNumber(value)
+}
+
+// tomlNumber converts a TOML integer or float to a json.Number in Go syntax.
+func tomlNumber(value string) (any, error) {
+	switch {
+	case tomlRadix.MatchString(value):
+		number, err := strconv.ParseUint(value, 0, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s", value)
+		}
+		return json.Number(strconv.FormatUint(number, 10)), nil
+	case tomlInt.MatchString(value), tomlFloat.MatchString(value):
+		return json.Number(strings.TrimPrefix(strings.ReplaceAll(value, "_", ""), "+")), nil
+	}
+	return nil, fmt.Errorf("unsupported value %s", value)
+}
+
+// splitList splits comma-separated items outside quotes and brackets, dropping empty trailing items.
+func splitList(s string) []string {
+	var items []string
+	depth, start := 0, 0
+	var quote rune
+	for i, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote && (quote == '\'' || i == 0 || s[i-1] != '\\') {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+		case r == ',' && depth == 0:
+			items = append(items, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+	if last := strings.TrimSpace(s[start:]); last != "" {
+		items = append(items, last)
+	}
+	return items
+}
+
+// stripComment removes a # comment that starts the line or follows whitespace outside quotes.
+func stripComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote && (quote == '\'' || line[i-1] != '\\') {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// splitKey splits a dotted TOML key, keeping dots inside quoted parts.
+func splitKey(key string) []string {
+	var parts []string
+	var part strings.Builder
+	var quote rune
+	for _, r := range strings.TrimSpace(key) {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+				continue
+			}
+			part.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '.':
+			parts = append(parts, strings.TrimSpace(part.String()))
+			part.Reset()
+		default:
+			part.WriteRune(r)
+		}
+	}
+	return append(parts, strings.TrimSpace(part.String()))
+}
+
+func ensureTable(root map[string]any, path []string) (map[string]any, error) {
+	table := root
+	for _, key := range path {
+		switch next := table[key].(type) {
+		case map[string]any:
+			table = next
+		case nil:
+			created := map[string]any{}
+			table[key] = created
+			table = created
+		default:
+			return nil, fmt.Errorf("key %q is not a table", key)
+		}
+	}
+	return table, nil
+}
@@ -3,7 +3,11 @@ package godi
 import (
 	"errors"
 	"fmt"
+	"log/slog"
 	"reflect"
+	"strconv"
+	"sync"
+	"time"
 
 	"go.uber.org/dig"
 )
@@ -13,6 +17,12 @@ type containerConfig struct {
 	matchings        []any
 	modules          []Module
 	defaultLifecycle bool
+	checkUnused      bool
+	unusedRoots      []any
+	observers        []Observer
+	logger           *slog.Logger
+	startupTrace     bool
+	allErrors        bool
 }
 
 // Container wraps dig.Container with a tiny convenience layer.
@@ -22,6 +32,21 @@ type Container struct {
 	modules      []Module
 	matchings    []any
 	started      bool
+	checkUnused  bool
+	unusedRoots  []any
+	lifecycle    *Lifecycle
+	timings      *timingRecorder
+	logger       *slog.Logger
+	tracer       *startupTracer
+	allErrors    bool
+	// lazyProvided holds the Lazy[T] slots registered in the root scope, keyed by the Lazy type.
+	lazyProvided map[slotKey]bool
+	// lazyMu serialises the Lazy.Get calls of the current dig container.
+	lazyMu *sync.Mutex
+
+	// mu guards dependencies, modules, started and dig against concurrent Provide, Invoke and Runnables calls
+	// and readers such as DebugHandler.
+	mu sync.RWMutex
 }
 
 func NewContainer(opts ...ContainerOption) (*Container, error) {
@@ -45,8 +70,28 @@ func NewContainer(opts ...ContainerOption) (*Container, error) {
 		})
 	}
 
+	var tracer *startupTracer
+	if cfg.startupTrace {
+		tracer = &startupTracer{}
+	}
+
+	var lifecycle *Lifecycle
 	if cfg.defaultLifecycle {
-		cfg.dependencies = append(cfg.dependencies, NewDependency(NewLifecycle))
+		// The container keeps the default lifecycle so DebugHandler can report hook states.
+		lifecycle = NewLifecycle()
+		lifecycle.logger = cfg.logger
+		lifecycle.tracer = tracer
+		dep := NewDependency(func() *Lifecycle { return lifecycle })
+		dep.origin = NewLifecycle
+		cfg.dependencies = append(cfg.dependencies, dep)
+	}
+
+	observers := cfg.observers
+	if cfg.logger != nil {
+		observers = append(observers, loggingObserver{logger: cfg.logger})
+	}
+	if tracer != nil {
+		observers = append(observers, tracer)
 	}
 
 	cnt := &Container{
@@ -55,6 +100,13 @@ func NewContainer(opts ...ContainerOption) (*Container, error) {
 		modules:      modules,
 		matchings:    cfg.matchings,
 		started:      false,
+		checkUnused:  cfg.checkUnused,
+		unusedRoots:  cfg.unusedRoots,
+		lifecycle:    lifecycle,
+		timings:      &timingRecorder{observers: observers},
+		logger:       cfg.logger,
+		tracer:       tracer,
+		allErrors:    cfg.allErrors,
 	}
 
 	if err := cnt.append(CollectDependencies(cfg.dependencies...)); err != nil {
@@ -65,12 +117,40 @@ func NewContainer(opts ...ContainerOption) (*Container, error) {
 }
 
 func (c *Container) Invoke(consumer any) error {
+	container, err := c.startInvoke(consumer)
+	if err != nil {
+		return err
+	}
+	if c.logger == nil {
+		return container.Invoke(consumer)
+	}
+	started := time.Now()
+	err = container.Invoke(consumer)
+	c.logInvoke(consumer, started, err)
+	return err
+}
+
+// startInvoke marks the container started and registers the Lazy[T] providers that consumer asks for and no
+// constructor requested, so Invoke accepts Lazy parameters like constructors do. The returned dig container
+// no longer changes, so it is invoked without holding the lock.
+func (c *Container) startInvoke(consumer any) (*dig.Container, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.started = true
-	return c.dig.Invoke(consumer)
+	slots, err := lazySlotsOf(parseConstructorInputs(consumer), c.lazyProvided)
+	if err != nil {
+		return nil, err
+	}
+	if err := provideLazySlots(c.dig, slots, c.lazyMu); err != nil {
+		return nil, err
+	}
+	return c.dig, nil
 }
 
 // Provide appends dependencies to the container.
 func (c *Container) Provide(deps Dependencies) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	if c.started {
 		return errors.New("cannot provide after container has been started")
 	}
@@ -78,9 +158,13 @@ func (c *Container) Provide(deps Dependencies) error {
 }
 
 func (c *Container) Runnables() ([]Runnable, error) {
-	var result []Runnable
+	c.mu.Lock()
 	c.started = true
-	err := c.dig.Invoke(func(r runnables) {
+	container := c.dig
+	c.mu.Unlock()
+
+	var result []Runnable
+	err := container.Invoke(func(r runnables) {
 		result = r.Runnables
 	})
 	if err != nil {
@@ -104,37 +188,125 @@ func (c *Container) append(d Dependencies) error {
 	c.dependencies = next
 	built, err := c.build(false)
 	if err != nil {
+		if c.allErrors {
+			if all := c.ValidateAll(); all != nil {
+				err = all
+			}
+		}
 		c.dependencies = orig
+		c.log(slog.LevelError, "godi: build failed", slog.String(LogKeyError, err.Error()))
 		return err
 	}
 
 	c.dig = built.container
+	c.lazyMu = built.lazyMu
+	c.lazyProvided = map[slotKey]bool{}
+	for _, lazy := range built.lazySlots[rootScopeName] {
+		c.lazyProvided[slotKey{t: lazy.lazyType, name: lazy.slot.name}] = true
+	}
+	c.logBuild(built, len(orig))
 	return nil
 }
 
 // Validate checks that all registered dependencies are resolvable without running constructors.
 func (c *Container) Validate() error {
+	var err error
+	if c.allErrors {
+		err = c.ValidateAll()
+	} else {
+		err = c.validate()
+	}
+	if err != nil {
+		c.log(slog.LevelError, "godi: validation failed", slog.String(LogKeyError, err.Error()))
+	}
+	return err
+}
+
+func (c *Container) validate() error {
 	built, err := c.build(true)
 	if err != nil {
 		return err
 	}
 
+	var first error
+	c.checkBuilt(built, func(err error) bool {
+		first = err
+		return false
+	})
+	return first
+}
+
+// checkBuilt runs the WithCheck checks of every provider of a dry build, invokes every provider and lazy
+// slot and applies the unused check, passing each failure to report; it stops as soon as report returns false.
+func (c *Container) checkBuilt(built *buildResult, report func(err error) bool) {
+	for _, provider := range built.rootProviders {
+		for _, err := range checkErrors(provider) {
+			if !report(err) {
+				return
+			}
+		}
+	}
+	for _, moduleName := range sortedScopeNames(built.moduleProviders) {
+		for _, provider := range built.moduleProviders[moduleName] {
+			for _, err := range checkErrors(provider) {
+				if !report(fmt.Errorf("module %s: %w", moduleName, err)) {
+					return
+				}
+			}
+		}
+	}
+
 	for _, provider := range built.rootProviders {
 		if err := invokeProvider(built.container, provider.dep); err != nil {
-			return err
+			if !report(c.missingError(built, rootScopeName, provider, err)) {
+				return
+			}
 		}
 	}
 
-	for moduleName, providers := range built.moduleProviders {
+	for _, moduleName := range sortedScopeNames(built.moduleProviders) {
 		scope := built.scopes[moduleName]
-		for _, provider := range providers {
+		for _, provider := range built.moduleProviders[moduleName] {
 			if err := invokeProvider(scope, provider.dep); err != nil {
-				return fmt.Errorf("module %s: %w", moduleName, err)
+				if !report(fmt.Errorf("module %s: %w", moduleName, c.missingError(built, moduleName, provider, err))) {
+					return
+				}
 			}
 		}
 	}
 
-	return nil
+	for _, scopeName := range sortedScopeNames(built.lazySlots) {
+		for _, lazy := range built.lazySlots[scopeName] {
+			fn, err := buildValidationInvokeForSlot(lazy.slot)
+			if err != nil {
+				if !report(err) {
+					return
+				}
+				continue
+			}
+			if scopeName == rootScopeName {
+				err = built.container.Invoke(fn)
+			} else {
+				err = built.scopes[scopeName].Invoke(fn)
+			}
+			if err != nil {
+				if !report(fmt.Errorf("lazy %s: %w", slotLabel(lazy.slot), err)) {
+					return
+				}
+			}
+		}
+	}
+
+	if c.checkUnused {
+		unused, err := c.Unused(c.unusedRoots...)
+		if err != nil {
+			report(err)
+			return
+		}
+		if !unused.Empty() {
+			report(errors.New(unused.String()))
+		}
+	}
 }
 
 type buildResult struct {
@@ -142,6 +314,11 @@ type buildResult struct {
 	scopes          map[string]*dig.Scope
 	rootProviders   []depEntry
 	moduleProviders map[string][]depEntry
+	lazySlots       map[string][]lazySlot
+	lazyMu          *sync.Mutex
+
+	globalResolution  resolvedScope
+	moduleResolutions map[string]resolvedScope
 }
 
 func (c *Container) build(dry bool) (*buildResult, error) {
@@ -160,19 +337,36 @@ func (c *Container) build(dry bool) (*buildResult, error) {
 	if err := validateDecorators(globalResolution.decorators, globalResolution.slots); err != nil {
 		return nil, err
 	}
+	if err := validateCycles(c.GraphModules()); err != nil {
+		return nil, err
+	}
+
+	var recorder *timingRecorder
+	if !dry {
+		recorder = c.timings
+		if len(recorder.observers) > 0 {
+			recorder.dependencies = eventDependencies(c.GraphModules())
+		}
+	}
 
 	root, scopes := buildDigContainer(c.modules, dry)
-	rootProviders, err := provideRootProviders(root, globalResolution)
+	rootProviders, err := provideRootProviders(root, globalResolution, recorder)
+	if err != nil {
+		return nil, err
+	}
+
+	moduleProviders, err := provideModuleProviders(scopes, moduleResolutions, globalResolution, recorder)
 	if err != nil {
 		return nil, err
 	}
 
-	moduleProviders, err := provideModuleProviders(scopes, moduleResolutions, globalResolution)
+	lazyMu := &sync.Mutex{}
+	lazySlots, err := provideLazyProviders(root, scopes, rootProviders, globalResolution, moduleResolutions, lazyMu)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := applyDecorators(root, scopes, globalResolution, moduleResolutions); err != nil {
+	if err := applyDecorators(root, scopes, globalResolution, moduleResolutions, recorder); err != nil {
 		return nil, err
 	}
 
@@ -181,25 +375,22 @@ func (c *Container) build(dry bool) (*buildResult, error) {
 		scopes:          scopes,
 		rootProviders:   rootProviders,
 		moduleProviders: moduleProviders,
+		lazySlots:       lazySlots,
+		lazyMu:          lazyMu,
+
+		globalResolution:  globalResolution,
+		moduleResolutions: moduleResolutions,
 	}, nil
 }
 
 func buildRootEntries(deps []Dependency) []depEntry {
-	rootEntries := make([]depEntry, 0, len(deps))
-	for i, dep := range deps {
-		rootEntries = append(rootEntries, depEntry{dep: dep, idx: i})
-	}
-	return rootEntries
+	return buildEntries(deps, "")
 }
 
 func buildModuleResolutions(modules []Module) (map[string]resolvedScope, error) {
 	moduleResolutions := map[string]resolvedScope{}
 	for _, module := range modules {
-		entries := make([]depEntry, 0, len(module.Dependencies.List()))
-		for i, dep := range module.Dependencies.List() {
-			entries = append(entries, depEntry{dep: dep, idx: i, module: module.Name})
-		}
-		res, err := resolveEntries(entries)
+		res, err := resolveEntries(buildModuleEntries(module))
 		if err != nil {
 			return nil, fmt.Errorf("module %s: %w", module.Name, err)
 		}
@@ -208,6 +399,26 @@ func buildModuleResolutions(modules []Module) (map[string]resolvedScope, error)
 	return moduleResolutions, nil
 }
 
+func buildModuleEntries(module Module) []depEntry {
+	return buildEntries(module.Dependencies.List(), module.Name)
+}
+
+// buildEntries numbers deps in declaration order and gives each one its graph node ID. A repeated ID, such
+// as the same key on a Provide and its Replace, gets its occurrence in the scope appended ("#2").
+func buildEntries(deps []Dependency, module string) []depEntry {
+	entries := make([]depEntry, 0, len(deps))
+	occurrences := map[string]int{}
+	for i, dep := range deps {
+		id := buildProviderID(dep, describeProvider(dep, i))
+		occurrences[id]++
+		if n := occurrences[id]; n > 1 {
+			id += "#" + strconv.Itoa(n)
+		}
+		entries = append(entries, depEntry{dep: dep, idx: i, module: module, id: id})
+	}
+	return entries
+}
+
 func buildGlobalEntries(rootEntries []depEntry, moduleResolutions map[string]resolvedScope) []depEntry {
 	globalEntries := make([]depEntry, 0, len(rootEntries))
 	globalEntries = append(globalEntries, rootEntries...)
@@ -237,13 +448,17 @@ func buildDigContainer(modules []Module, dry bool) (*dig.Container, map[string]*
 	return root, scopes
 }
 
-func provideRootProviders(root *dig.Container, resolution resolvedScope) ([]depEntry, error) {
+func provideRootProviders(
+	root *dig.Container,
+	resolution resolvedScope,
+	recorder *timingRecorder,
+) ([]depEntry, error) {
 	rootProviders := make([]depEntry, 0)
 	for _, provider := range resolution.providers {
 		if provider.module != "" {
 			continue
 		}
-		if err := provideDependency(root, provider.dep, false); err != nil {
+		if err := provideDependency(root, provider.dep, false, recorder.provideOptions(provider)...); err != nil {
 			return nil, err
 		}
 		rootProviders = append(rootProviders, provider)
@@ -255,6 +470,7 @@ func provideModuleProviders(
 	scopes map[string]*dig.Scope,
 	moduleResolutions map[string]resolvedScope,
 	globalResolution resolvedScope,
+	recorder *timingRecorder,
 ) (map[string][]depEntry, error) {
 	moduleProviders := map[string][]depEntry{}
 	for moduleName, res := range moduleResolutions {
@@ -266,7 +482,7 @@ func provideModuleProviders(
 		scope := scopes[moduleName]
 		for _, provider := range res.providers {
 			if provider.dep.private {
-				if err := provideDependency(scope, provider.dep, false); err != nil {
+				if err := provideDependency(scope, provider.dep, false, recorder.provideOptions(provider)...); err != nil {
 					return nil, err
 				}
 				moduleProviders[moduleName] = append(moduleProviders[moduleName], provider)
@@ -281,7 +497,7 @@ func provideModuleProviders(
 				continue
 			}
 
-			if err := provideDependency(scope, provider.dep, true); err != nil {
+			if err := provideDependency(scope, provider.dep, true, recorder.provideOptions(provider)...); err != nil {
 				return nil, err
 			}
 			moduleProviders[moduleName] = append(moduleProviders[moduleName], provider)
@@ -291,21 +507,57 @@ func provideModuleProviders(
 	return moduleProviders, nil
 }
 
+// provideLazyProviders registers Lazy[T] providers in every scope whose constructors request them.
+// Each Lazy resolves in the scope that provides it, so module-private dependencies stay reachable.
+func provideLazyProviders(
+	root *dig.Container,
+	scopes map[string]*dig.Scope,
+	rootProviders []depEntry,
+	globalResolution resolvedScope,
+	moduleResolutions map[string]resolvedScope,
+	mu *sync.Mutex,
+) (map[string][]lazySlot, error) {
+	lazySlots := map[string][]lazySlot{}
+
+	rootLazy, err := collectLazySlots(rootProviders, globalResolution.decorators)
+	if err != nil {
+		return nil, err
+	}
+	if err := provideLazySlots(root, rootLazy, mu); err != nil {
+		return nil, err
+	}
+	lazySlots[rootScopeName] = rootLazy
+
+	for moduleName, res := range moduleResolutions {
+		moduleLazy, err := collectLazySlots(res.providers, res.decorators)
+		if err != nil {
+			return nil, fmt.Errorf("module %s: %w", moduleName, err)
+		}
+		if err := provideLazySlots(scopes[moduleName], moduleLazy, mu); err != nil {
+			return nil, fmt.Errorf("module %s: %w", moduleName, err)
+		}
+		lazySlots[moduleName] = moduleLazy
+	}
+
+	return lazySlots, nil
+}
+
 func applyDecorators(
 	root *dig.Container,
 	scopes map[string]*dig.Scope,
 	globalResolution resolvedScope,
 	moduleResolutions map[string]resolvedScope,
+	recorder *timingRecorder,
 ) error {
 	for _, decorator := range globalResolution.decorators {
-		if err := root.Decorate(decorator.dep.constructor); err != nil {
+		if err := root.Decorate(decorator.dep.constructor, recorder.decorateOptions(decorator)...); err != nil {
 			return err
 		}
 	}
 	for moduleName, res := range moduleResolutions {
 		scope := scopes[moduleName]
 		for _, decorator := range res.decorators {
-			if err := scope.Decorate(decorator.dep.constructor); err != nil {
+			if err := scope.Decorate(decorator.dep.constructor, recorder.decorateOptions(decorator)...); err != nil {
 				return err
 			}
 		}
@@ -338,26 +590,11 @@ func applyMatchings(dependency *Dependency, matchings []any) error {
 	var asInterfaces []any
 
 	for _, matching := range matchings {
-		if m, ok := matching.(Matching); ok {
-			if err := m.Error(); err != nil {
-				return fmt.Errorf("matching has an error: %w", err)
-			}
-			depType := dependency.Type()
-			if depType == m.Origin() || (depType != nil && depType.Kind() == reflect.Pointer && depType.Elem() == m.Origin()) {
-				asInterfaces = append(asInterfaces, m.interfaces...)
-			}
-			continue
-		}
-
-		if isPointerToInterface(matching) {
-			t := dependency.Type()
-			if t != nil && t.Implements(reflect.TypeOf(matching).Elem()) {
-				asInterfaces = append(asInterfaces, matching)
-			}
-			continue
+		interfaces, err := matchingInterfaces(*dependency, matching)
+		if err != nil {
+			return err
 		}
-
-		return fmt.Errorf("matching must be a pointer to interface or Matching, got %T", matching)
+		asInterfaces = append(asInterfaces, interfaces...)
 	}
 
 	if len(asInterfaces) == 0 {
@@ -367,9 +604,33 @@ func applyMatchings(dependency *Dependency, matchings []any) error {
 	return dependency.AddMatchingInterface(asInterfaces...)
 }
 
+// matchingInterfaces returns the interfaces a single matching binds the dependency to.
+func matchingInterfaces(dependency Dependency, matching any) ([]any, error) {
+	if m, ok := matching.(Matching); ok {
+		if err := m.Error(); err != nil {
+			return nil, fmt.Errorf("matching has an error: %w", err)
+		}
+		depType := dependency.Type()
+		if depType == m.Origin() || (depType != nil && depType.Kind() == reflect.Pointer && depType.Elem() == m.Origin()) {
+			return m.interfaces, nil
+		}
+		return nil, nil
+	}
+
+	if isPointerToInterface(matching) {
+		t := dependency.Type()
+		if t != nil && t.Implements(reflect.TypeOf(matching).Elem()) {
+			return []any{matching}, nil
+		}
+		return nil, nil
+	}
+
+	return nil, fmt.Errorf("matching must be a pointer to interface or Matching, got %T", matching)
+}
+
 func provideDependency(scope interface {
 	Provide(constructor any, opts ...dig.ProvideOption) error
-}, dep Dependency, export bool,
+}, dep Dependency, export bool, extra ...dig.ProvideOption,
 ) error {
 	if dep.kind == dependencyKindDecorate {
 		return errors.New("decorate dependencies cannot be provided")
@@ -379,11 +640,8 @@ func provideDependency(scope interface {
 		return errors.New("replace is not supported for group dependencies")
 	}
 
-	if dep.name != nil && dependencyGroup(dep) != "" {
-		return errors.New("invalid dependency options: WithName cannot be used with WithGroup or Runnable")
-	}
-	if dep.IsRunnable() && dep.group != nil {
-		return errors.New("invalid dependency options: Runnable cannot be used with WithGroup")
+	if err := validateProvideOptions(dep); err != nil {
+		return err
 	}
 
 	options := make([]dig.ProvideOption, 0)
@@ -403,9 +661,21 @@ func provideDependency(scope interface {
 		options = append(options, dig.Export(true))
 	}
 
+	options = append(options, extra...)
+
 	return scope.Provide(dep.constructor, options...)
 }
 
+func validateProvideOptions(dep Dependency) error {
+	if dep.name != nil && dependencyGroup(dep) != "" {
+		return errors.New("invalid dependency options: WithName cannot be used with WithGroup or Runnable")
+	}
+	if dep.IsRunnable() && dep.group != nil {
+		return errors.New("invalid dependency options: Runnable cannot be used with WithGroup")
+	}
+	return nil
+}
+
 func invokeProvider(scope interface {
 	Invoke(function any, opts ...dig.InvokeOption) error
 }, dep Dependency,
@@ -430,18 +700,30 @@ func invokeProvider(scope interface {
 }
 
 func validateDecorators(decorators []depEntry, available map[slotKey]depEntry) error {
+	if errs := decoratorErrors(decorators, available); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// decoratorErrors reports every decorator slot without a provider in available.
+func decoratorErrors(decorators []depEntry, available map[slotKey]depEntry) []error {
+	var errs []error
 	for _, decorator := range decorators {
 		slots, err := decoratorSlots(decorator.dep)
 		if err != nil {
-			return err
+			errs = append(errs, err)
+			continue
 		}
 		for _, slot := range slots {
 			if _, ok := available[slot]; !ok {
-				return fmt.Errorf("cannot decorate slot %s: no provider", slotLabel(slot))
+				err := newSlotError(WiringUndecoratable, slot, decorator)
+				err.Suggestion = suggestSlot(slot, available)
+				errs = append(errs, err)
 			}
 		}
 	}
-	return nil
+	return errs
 }
 
 func mergeSlots(a, b map[slotKey]depEntry) map[slotKey]depEntry {
@@ -1,5 +1,7 @@
 package godi
 
+import "log/slog"
+
 type ContainerOption func(c *containerConfig)
 
 // WithDependencies adds dependencies to the container.
@@ -32,3 +34,47 @@ func WithDefaultLifecycle() ContainerOption {
 		c.defaultLifecycle = true
 	}
 }
+
+// WithUnusedCheck makes Validate fail when providers are unreachable from the given
+// consumers and registered runnables, or when matchings never matched (see Container.Unused).
+func WithUnusedCheck(roots ...any) ContainerOption {
+	return func(c *containerConfig) {
+		c.checkUnused = true
+		c.unusedRoots = append(c.unusedRoots, roots...)
+	}
+}
+
+// WithInstrumentation notifies observers about every constructor and decorator call the container makes:
+// slot, module, start, duration, error, recovered panic and the graph IDs of the call's dependencies.
+// Use it for logging, metrics or tracing; see ConstructorEvent.
+func WithInstrumentation(observers ...Observer) ContainerOption {
+	return func(c *containerConfig) {
+		c.observers = append(c.observers, observers...)
+	}
+}
+
+// WithLogger makes the container log what it provides, replaces and decorates, which module providers win
+// root slots, Invoke calls, constructor calls, validation failures and hooks of the default lifecycle
+// (WithDefaultLifecycle). Registrations and calls are logged at debug level, overrides and lifecycle hooks
+// at info level and failures at error level.
+func WithLogger(logger *slog.Logger) ContainerOption {
+	return func(c *containerConfig) {
+		c.logger = logger
+	}
+}
+
+// WithStartupTrace records constructor, decorator and default lifecycle hook calls as a timeline for
+// Container.WriteStartupTrace.
+func WithStartupTrace() ContainerOption {
+	return func(c *containerConfig) {
+		c.startupTrace = true
+	}
+}
+
+// WithAllErrors makes NewContainer, Provide and Validate report every wiring problem as a *ValidationError
+// (see Container.ValidateAll) instead of failing on the first one.
+func WithAllErrors() ContainerOption {
+	return func(c *containerConfig) {
+		c.allErrors = true
+	}
+}
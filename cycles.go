@@ -0,0 +1,203 @@
+package godi
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Cycles returns every elementary dependency cycle in the graph.
+// Each cycle is ordered along the dependency direction and starts at the node declared first;
+// the closing edge back to the first node is implied.
+func (g Graph) Cycles() [][]ProviderNode {
+	index := map[string]int{}
+	for i, node := range g.Providers {
+		if _, ok := index[node.ID]; !ok {
+			index[node.ID] = i
+		}
+	}
+
+	adjacency := make([][]int, len(g.Providers))
+	seenEdge := map[[2]int]bool{}
+	for _, edge := range g.Edges {
+		if edge.Missing || edge.Lazy {
+			continue
+		}
+		from, okFrom := index[edge.From]
+		to, okTo := index[edge.To]
+		if !okFrom || !okTo || seenEdge[[2]int{from, to}] {
+			continue
+		}
+		seenEdge[[2]int{from, to}] = true
+		adjacency[from] = append(adjacency[from], to)
+	}
+
+	component := stronglyConnected(adjacency)
+	size := map[int]int{}
+	for _, c := range component {
+		size[c]++
+	}
+
+	// Johnson's algorithm: for every start node, in declaration order, enumerate the circuits through it
+	// that stay inside its strongly connected component and visit only later nodes, so each cycle is
+	// reported once from its first node. Blocked nodes keep fruitless paths from being walked again.
+	cycles := make([][]ProviderNode, 0)
+	blocked := make([]bool, len(adjacency))
+	blockedBy := make([]map[int]bool, len(adjacency))
+	for start := range adjacency {
+		if size[component[start]] == 1 && !slices.Contains(adjacency[start], start) {
+			continue
+		}
+		allowed := func(node int) bool { return node >= start && component[node] == component[start] }
+		for i := range adjacency {
+			blocked[i] = false
+			blockedBy[i] = nil
+		}
+
+		var unblock func(node int)
+		unblock = func(node int) {
+			blocked[node] = false
+			for other := range blockedBy[node] {
+				delete(blockedBy[node], other)
+				if blocked[other] {
+					unblock(other)
+				}
+			}
+		}
+
+		path := []int{}
+		var circuit func(node int) bool
+		circuit = func(node int) bool {
+			found := false
+			path = append(path, node)
+			blocked[node] = true
+			for _, next := range adjacency[node] {
+				switch {
+				case !allowed(next):
+				case next == start:
+					cycle := make([]ProviderNode, 0, len(path))
+					for _, i := range path {
+						cycle = append(cycle, g.Providers[i])
+					}
+					cycles = append(cycles, cycle)
+					found = true
+				case !blocked[next] && circuit(next):
+					found = true
+				}
+			}
+			if found {
+				unblock(node)
+			} else {
+				for _, next := range adjacency[node] {
+					if allowed(next) {
+						if blockedBy[next] == nil {
+							blockedBy[next] = map[int]bool{}
+						}
+						blockedBy[next][node] = true
+					}
+				}
+			}
+			path = path[:len(path)-1]
+			return found
+		}
+		circuit(start)
+	}
+
+	return cycles
+}
+
+// stronglyConnected labels every node with the strongly connected component it belongs to (Tarjan).
+func stronglyConnected(adjacency [][]int) []int {
+	const unvisited = -1
+	index := make([]int, len(adjacency))
+	low := make([]int, len(adjacency))
+	onStack := make([]bool, len(adjacency))
+	component := make([]int, len(adjacency))
+	for i := range index {
+		index[i] = unvisited
+	}
+
+	var stack []int
+	next, components := 0, 0
+	var visit func(node int)
+	visit = func(node int) {
+		index[node], low[node] = next, next
+		next++
+		stack = append(stack, node)
+		onStack[node] = true
+		for _, to := range adjacency[node] {
+			switch {
+			case index[to] == unvisited:
+				visit(to)
+				low[node] = min(low[node], low[to])
+			case onStack[to]:
+				low[node] = min(low[node], index[to])
+			}
+		}
+		if low[node] != index[node] {
+			return
+		}
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component[top] = components
+			if top == node {
+				break
+			}
+		}
+		components++
+	}
+	for node := range adjacency {
+		if index[node] == unvisited {
+			visit(node)
+		}
+	}
+	return component
+}
+
+// cycleEdges returns the set of "from|to" edges participating in any cycle.
+func (g Graph) cycleEdges() map[string]bool {
+	edges := map[string]bool{}
+	for _, cycle := range g.Cycles() {
+		for i, node := range cycle {
+			next := cycle[(i+1)%len(cycle)]
+			edges[node.ID+"|"+next.ID] = true
+		}
+	}
+	return edges
+}
+
+func formatCycle(cycle []ProviderNode) string {
+	if len(cycle) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(cycle)+1)
+	for _, node := range cycle {
+		parts = append(parts, providerNodeLabel(node))
+	}
+	parts = append(parts, providerNodeLabel(cycle[0]))
+	return strings.Join(parts, " -> ")
+}
+
+// validateCycles reports dependency cycles found in any scope graph.
+func validateCycles(graphs map[string]Graph) error {
+	return errors.Join(cycleErrors(graphs)...)
+}
+
+func cycleErrors(graphs map[string]Graph) []error {
+	seen := map[string]bool{}
+	var errs []error
+	for _, name := range sortedScopeNames(graphs) {
+		for _, cycle := range graphs[name].Cycles() {
+			label := formatCycle(cycle)
+			if seen[label] {
+				continue
+			}
+			seen[label] = true
+			errs = append(errs, fmt.Errorf("dependency cycle: %s", label))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,137 @@
+package godi_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/assurrussa/godi"
+)
+
+type (
+	cycleA struct{}
+	cycleB struct{}
+	cycleC struct{}
+)
+
+func cycleDeps() godi.Dependencies {
+	return godi.CollectDependencies(
+		godi.NewDependency(func(*cycleB) *cycleA { return &cycleA{} }),
+		godi.NewDependency(func(*cycleC) *cycleB { return &cycleB{} }),
+		godi.NewDependency(func(*cycleA) *cycleC { return &cycleC{} }),
+		godi.NewDependency(func(*cycleA) string { return "" }),
+	)
+}
+
+func TestGraphCyclesReturnsOrderedCycle(t *testing.T) {
+	t.Parallel()
+
+	g := godi.BuildGraph(cycleDeps())
+	cycles := g.Cycles()
+	if len(cycles) != 1 {
+		t.Fatalf("expected 1 cycle, got %d", len(cycles))
+	}
+
+	want := []string{"*godi_test.cycleA", "*godi_test.cycleB", "*godi_test.cycleC"}
+	if len(cycles[0]) != len(want) {
+		t.Fatalf("expected cycle of %d nodes, got %+v", len(want), cycles[0])
+	}
+	for i, node := range cycles[0] {
+		if node.Type != want[i] {
+			t.Fatalf("expected cycle order %v, got %q at %d", want, node.Type, i)
+		}
+		if node.File == "" || node.Line == 0 {
+			t.Fatalf("expected source location on cycle node, got %+v", node)
+		}
+	}
+
+	dot := g.DOT()
+	if strings.Count(dot, "color=red") != 3 {
+		t.Fatalf("expected 3 highlighted cycle edges, got:\n%s", dot)
+	}
+}
+
+func TestContainerReportsReadableCycle(t *testing.T) {
+	t.Parallel()
+
+	_, err := godi.NewContainer(godi.WithDependencies(cycleDeps()))
+	if err == nil {
+		t.Fatal("expected cycle error, got nil")
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, "dependency cycle:") || strings.Count(msg, " -> ") != 3 {
+		t.Fatalf("expected readable cycle path, got %q", msg)
+	}
+}
+
+func TestGraphCyclesEmptyForAcyclicGraph(t *testing.T) {
+	t.Parallel()
+
+	g := godi.BuildGraph(godi.CollectDependencies(
+		godi.NewDependency(func() string { return testBase }),
+		godi.Decorate(func(s string) string { return s + "!" }),
+	))
+	if cycles := g.Cycles(); len(cycles) != 0 {
+		t.Fatalf("expected no cycles, got %+v", cycles)
+	}
+}
+
+// layeredDeps builds layers of width providers where every provider requires all providers of the previous
+// layer; each provider returns its own [n]byte type.
+func layeredDeps(layers, width int) godi.Dependencies {
+	deps := make([]godi.Dependency, 0, layers*width)
+	var previous []reflect.Type
+	for layer := range layers {
+		current := make([]reflect.Type, 0, width)
+		for i := range width {
+			out := reflect.ArrayOf(layer*width+i+1, reflect.TypeFor[byte]())
+			ctor := reflect.MakeFunc(
+				reflect.FuncOf(previous, []reflect.Type{out}, false),
+				func([]reflect.Value) []reflect.Value { return []reflect.Value{reflect.New(out).Elem()} },
+			)
+			deps = append(deps, godi.NewDependency(ctor.Interface()))
+			current = append(current, out)
+		}
+		previous = current
+	}
+	return godi.CollectDependencies(deps...)
+}
+
+func TestGraphCyclesFastOnLayeredGraph(t *testing.T) {
+	t.Parallel()
+
+	deps := layeredDeps(18, 3)
+	started := time.Now()
+	if _, err := godi.NewContainer(godi.WithDependencies(deps)); err != nil {
+		t.Fatalf("NewContainer: %v", err)
+	}
+	if cycles := godi.BuildGraph(deps).Cycles(); len(cycles) != 0 {
+		t.Fatalf("expected no cycles, got %d", len(cycles))
+	}
+	if elapsed := time.Since(started); elapsed > 2*time.Second {
+		t.Fatalf("expected cycle detection on 54 layered providers to be fast, took %s", elapsed)
+	}
+}
+
+func TestGraphCyclesEnumeratesOverlappingCycles(t *testing.T) {
+	t.Parallel()
+
+	g := godi.Graph{Providers: []godi.ProviderNode{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "d"}}}
+	for _, edge := range [][2]string{{"a", "b"}, {"b", "a"}, {"b", "c"}, {"c", "a"}, {"c", "b"}, {"d", "d"}} {
+		g.Edges = append(g.Edges, godi.ProviderEdge{From: edge[0], To: edge[1]})
+	}
+
+	var got []string
+	for _, cycle := range g.Cycles() {
+		ids := make([]string, 0, len(cycle))
+		for _, node := range cycle {
+			ids = append(ids, node.ID)
+		}
+		got = append(got, strings.Join(ids, ">"))
+	}
+	want := []string{"a>b", "a>b>c", "b>c", "d"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Fatalf("expected cycles %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,147 @@
+package godi
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"path"
+)
+
+// ModuleInfo summarizes a registered module.
+type ModuleInfo struct {
+	Name         string `json:"name"`
+	Dependencies int    `json:"dependencies"`
+	Private      int    `json:"private"`
+}
+
+var debugEndpoints = []struct {
+	name        string
+	description string
+}{
+	{name: "graph.dot", description: "root graph in DOT format"},
+	{name: "graph.json", description: "root and module graphs with modules and overrides"},
+	{name: "graph.html", description: "interactive root graph viewer"},
+	{name: "modules", description: "registered modules"},
+	{name: "overrides", description: "replaced, shadowed and decorated providers"},
+	{name: "lifecycle", description: "hook states of the default lifecycle"},
+	{name: "timings", description: "constructor and decorator timings"},
+}
+
+// DebugHandler serves container introspection, similar to net/http/pprof.
+// Mount it under a prefix on any mux:
+//
+//	mux.Handle("/debug/godi/", godi.DebugHandler(cnt))
+//
+// Endpoints are selected by the last path element: graph.dot, graph.json, graph.html, modules,
+// overrides, lifecycle and timings; anything else serves an index page.
+// Lifecycle hook states are reported for the lifecycle registered with WithDefaultLifecycle.
+// The handler is safe to use concurrently with Provide, Invoke, Runnables and lifecycle calls.
+func DebugHandler(c *Container) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		endpoint := path.Base(r.URL.Path)
+		snapshot := c.debugSnapshot(endpoint)
+
+		switch endpoint {
+		case "graph.dot":
+			w.Header().Set("Content-Type", "text/vnd.graphviz; charset=utf-8")
+			_, _ = w.Write([]byte(snapshot.graph.DOT()))
+		case "graph.json":
+			data, err := json.MarshalIndent(snapshot.document, "", "  ")
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write(data)
+		case "graph.html":
+			page, err := snapshot.graph.HTML()
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write(page)
+		case "modules":
+			writeDebugJSON(w, snapshot.modules)
+		case "overrides":
+			writeDebugJSON(w, snapshot.overrides)
+		case "lifecycle":
+			states := make([]HookState, 0)
+			if c.lifecycle != nil {
+				states = append(states, c.lifecycle.States()...)
+			}
+			writeDebugJSON(w, states)
+		case "timings":
+			timings := make([]ConstructorTiming, 0)
+			writeDebugJSON(w, append(timings, c.ConstructorTimings()...))
+		default:
+			writeDebugIndex(w)
+		}
+	})
+}
+
+// debugSnapshot holds the container state a debug endpoint renders.
+type debugSnapshot struct {
+	graph     Graph
+	document  containerGraphDocument
+	modules   []ModuleInfo
+	overrides []OverrideInfo
+}
+
+// debugSnapshot collects the state for endpoint under the read lock; the handler renders and writes it after
+// the lock is released, so a slow client does not block Provide. Lifecycle states and timings have their own
+// locks.
+func (c *Container) debugSnapshot(endpoint string) debugSnapshot {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var snapshot debugSnapshot
+	switch endpoint {
+	case "graph.dot", "graph.html":
+		snapshot.graph = c.Graph()
+	case "graph.json":
+		snapshot.document = c.graphDocument()
+	case "modules":
+		snapshot.modules = c.moduleInfos()
+	case "overrides":
+		snapshot.overrides = c.Overrides()
+	}
+	return snapshot
+}
+
+func (c *Container) moduleInfos() []ModuleInfo {
+	infos := make([]ModuleInfo, 0, len(c.modules))
+	for _, module := range c.modules {
+		info := ModuleInfo{Name: module.Name}
+		for _, dep := range module.Dependencies.List() {
+			info.Dependencies++
+			if dep.private {
+				info.Private++
+			}
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+func writeDebugJSON(w http.ResponseWriter, value any) {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+func writeDebugIndex(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write([]byte("<!DOCTYPE html>\n<html><head><title>godi</title></head><body>\n<h1>godi</h1>\n<ul>\n"))
+	for _, endpoint := range debugEndpoints {
+		// Relative links keep the index working under any mount prefix that ends with a slash.
+		_, _ = fmt.Fprintf(w, "<li><a href=\"%s\">%s</a> - %s</li>\n",
+			endpoint.name, endpoint.name, html.EscapeString(endpoint.description))
+	}
+	_, _ = w.Write([]byte("</ul>\n</body></html>\n"))
+}
@@ -0,0 +1,242 @@
+package godi_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/assurrussa/godi"
+)
+
+type debugService struct{}
+
+func newDebugContainer(t *testing.T) *godi.Container {
+	t.Helper()
+
+	cnt, err := godi.NewContainer(
+		godi.WithDefaultLifecycle(),
+		godi.WithDependencies(godi.CollectDependencies(
+			godi.NewDependency(func() *debugService { return &debugService{} }),
+			godi.Decorate(func(s *debugService) *debugService { return s }),
+		)),
+		godi.WithModules(godi.NewModule("users", godi.CollectDependencies(
+			godi.NewDependency(func() string { return testBase }, godi.Private()),
+		))),
+	)
+	if err != nil {
+		t.Fatalf("NewContainer error: %v", err)
+	}
+	return cnt
+}
+
+func debugGet(t *testing.T, handler http.Handler, target string) (string, string) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET %s: status %d", target, rec.Code)
+	}
+	body, err := io.ReadAll(rec.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	return string(body), rec.Header().Get("Content-Type")
+}
+
+func TestDebugHandlerEndpoints(t *testing.T) {
+	t.Parallel()
+
+	cnt := newDebugContainer(t)
+	mux := http.NewServeMux()
+	mux.Handle("/debug/godi/", godi.DebugHandler(cnt))
+
+	index, _ := debugGet(t, mux, "/debug/godi/")
+	for _, want := range []string{"graph.dot", "graph.json", "modules", "overrides", "lifecycle", "timings"} {
+		if !strings.Contains(index, `href="`+want+`"`) {
+			t.Fatalf("expected index to link %s:\n%s", want, index)
+		}
+	}
+
+	dot, contentType := debugGet(t, mux, "/debug/godi/graph.dot")
+	if !strings.HasPrefix(dot, "digraph DI {") || !strings.HasPrefix(contentType, "text/vnd.graphviz") {
+		t.Fatalf("unexpected DOT response (%s):\n%s", contentType, dot)
+	}
+
+	graphJSON, _ := debugGet(t, mux, "/debug/godi/graph.json")
+	if !strings.Contains(graphJSON, `"scopes"`) {
+		t.Fatalf("unexpected graph JSON:\n%s", graphJSON)
+	}
+
+	page, _ := debugGet(t, mux, "/debug/godi/graph.html")
+	if !strings.HasPrefix(page, "<!DOCTYPE html>") {
+		t.Fatalf("unexpected graph page:\n%.200s", page)
+	}
+
+	modulesBody, _ := debugGet(t, mux, "/debug/godi/modules")
+	var modules []godi.ModuleInfo
+	if err := json.Unmarshal([]byte(modulesBody), &modules); err != nil {
+		t.Fatalf("decode modules: %v", err)
+	}
+	if len(modules) != 1 || modules[0].Name != "users" || modules[0].Private != 1 {
+		t.Fatalf("unexpected modules: %+v", modules)
+	}
+
+	overridesBody, _ := debugGet(t, mux, "/debug/godi/overrides")
+	var overrides []godi.OverrideInfo
+	if err := json.Unmarshal([]byte(overridesBody), &overrides); err != nil {
+		t.Fatalf("decode overrides: %v", err)
+	}
+	if len(overrides) != 1 || overrides[0].Kind != godi.OverrideKindDecorate {
+		t.Fatalf("unexpected overrides: %+v", overrides)
+	}
+}
+
+func TestDebugHandlerLifecycleAndTimings(t *testing.T) {
+	t.Parallel()
+
+	cnt := newDebugContainer(t)
+	handler := godi.DebugHandler(cnt)
+
+	if body, _ := debugGet(t, handler, "/lifecycle"); strings.TrimSpace(body) != "[]" {
+		t.Fatalf("expected no hooks before start, got %s", body)
+	}
+
+	err := cnt.Invoke(func(l *godi.Lifecycle, _ *debugService) error {
+		l.Append(godi.Hook{OnStart: func(context.Context) error { return nil }})
+		l.Append(godi.Hook{OnStart: func(context.Context) error { return errors.New("boom") }})
+		return l.Start(context.Background())
+	})
+	if err == nil {
+		t.Fatal("expected lifecycle start to fail")
+	}
+
+	body, _ := debugGet(t, handler, "/lifecycle")
+	var states []godi.HookState
+	if err := json.Unmarshal([]byte(body), &states); err != nil {
+		t.Fatalf("decode lifecycle: %v", err)
+	}
+	if len(states) != 2 || states[0].State != godi.HookStateStopped ||
+		states[1].State != godi.HookStateFailed || states[1].Error != "boom" {
+		t.Fatalf("unexpected hook states: %+v", states)
+	}
+
+	body, _ = debugGet(t, handler, "/timings")
+	var timings []godi.ConstructorTiming
+	if err := json.Unmarshal([]byte(body), &timings); err != nil {
+		t.Fatalf("decode timings: %v", err)
+	}
+	kinds := map[string]bool{}
+	for _, timing := range timings {
+		kinds[timing.Kind+" "+timing.Type] = true
+	}
+	for _, want := range []string{"provide *godi.Lifecycle", "provide *godi_test.debugService", "decorate *godi_test.debugService"} {
+		if !kinds[want] {
+			t.Fatalf("expected timing %q, got %+v", want, timings)
+		}
+	}
+	for _, timing := range timings {
+		if timing.Type == "*godi.Lifecycle" && !strings.HasSuffix(timing.Constructor, "godi.NewLifecycle") {
+			t.Fatalf("expected the default lifecycle to be reported as NewLifecycle, got %q", timing.Constructor)
+		}
+	}
+}
+
+func TestDebugHandlerConcurrentWithProvide(t *testing.T) {
+	t.Parallel()
+
+	cnt := newDebugContainer(t)
+	handler := godi.DebugHandler(cnt)
+
+	var wg sync.WaitGroup
+	for i := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, endpoint := range []string{"/graph.json", "/overrides", "/modules", "/timings"} {
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, endpoint, nil))
+				if rec.Code != http.StatusOK {
+					t.Errorf("GET %s (%d): status %d", endpoint, i, rec.Code)
+				}
+			}
+		}()
+	}
+	for i := range 4 {
+		dep := godi.NewSingleDependency(func() int { return i }, godi.WithName(strconv.Itoa(i)))
+		if err := cnt.Provide(dep); err != nil {
+			t.Fatalf("Provide error: %v", err)
+		}
+	}
+	wg.Wait()
+}
+
+func TestLifecycleStates(t *testing.T) {
+	t.Parallel()
+
+	l := godi.NewLifecycle()
+	l.Append(godi.Hook{})
+	l.Append(godi.Hook{OnStop: func(context.Context) error { return errors.New("stop failed") }})
+
+	states := l.States()
+	if len(states) != 2 || states[0].State != godi.HookStatePending || states[1].Index != 1 {
+		t.Fatalf("unexpected states before start: %+v", states)
+	}
+
+	if err := l.Start(context.Background()); err != nil {
+		t.Fatalf("Start error: %v", err)
+	}
+	if states := l.States(); states[0].State != godi.HookStateStarted || states[1].State != godi.HookStateStarted {
+		t.Fatalf("unexpected states after start: %+v", states)
+	}
+
+	if err := l.Stop(context.Background()); err == nil {
+		t.Fatal("expected stop error")
+	}
+	states = l.States()
+	if states[0].State != godi.HookStateStopped || states[1].State != godi.HookStateFailed || states[1].Error != "stop failed" {
+		t.Fatalf("unexpected states after stop: %+v", states)
+	}
+}
+
+func TestContainerConcurrentProvideAndInvoke(t *testing.T) {
+	t.Parallel()
+
+	cnt := newDebugContainer(t)
+	handler := godi.DebugHandler(cnt)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if err := cnt.Invoke(func(*debugService) {}); err != nil {
+			t.Errorf("Invoke error: %v", err)
+		}
+		if _, err := cnt.Runnables(); err != nil {
+			t.Errorf("Runnables error: %v", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for _, endpoint := range []string{"/graph.dot", "/graph.html", "/graph.json"} {
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, endpoint, nil))
+		}
+	}()
+	for i := range 4 {
+		dep := godi.NewSingleDependency(func() int { return i }, godi.WithName(strconv.Itoa(i)))
+		if err := cnt.Provide(dep); err != nil {
+			if !strings.Contains(err.Error(), "after container has been started") {
+				t.Fatalf("Provide error: %v", err)
+			}
+			break
+		}
+	}
+	wg.Wait()
+}
@@ -7,11 +7,13 @@ import (
 
 type Dependency struct {
 	constructor        any
+	origin             any // user constructor behind a generated one, used for diagnostics
 	matchingInterfaces []any
 	key                *string
 	name               *string
 	group              *string
 	private            bool
+	checks             []func() error
 	kind               dependencyKind
 	err                error
 }
@@ -30,3 +30,10 @@ func WithGroup(group string) DependencyOption {
 func Private() DependencyOption {
 	return func(d *Dependency) { d.private = true }
 }
+
+// WithCheck attaches a check that Validate runs for the winning provider of a slot without calling the
+// constructor, for example to verify configuration the constructor will read. A failure is reported as a
+// WiringError of kind WiringCheckFailed.
+func WithCheck(check func() error) DependencyOption {
+	return func(d *Dependency) { d.checks = append(d.checks, check) }
+}
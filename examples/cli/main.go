@@ -0,0 +1,29 @@
+// Registration package for the godi command line:
+//
+//	go run ./cmd/godi -pkg ./examples/cli graph -format mermaid
+//	go run ./examples/cli validate
+package main
+
+import (
+	"os"
+
+	"github.com/assurrussa/godi"
+	"github.com/assurrussa/godi/cli"
+)
+
+type (
+	Config struct{ DSN string }
+	DB     struct{ DSN string }
+	Server struct{ DB *DB }
+)
+
+func main() {
+	os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr,
+		godi.WithModules(godi.NewModule("storage", godi.CollectDependencies(
+			godi.NewDependency(func() Config { return Config{DSN: "postgres://localhost"} }, godi.Private()),
+			godi.NewDependency(func(cfg Config) *DB { return &DB{DSN: cfg.DSN} }),
+		))),
+		godi.WithDependencies(godi.NewSingleDependency(func(db *DB) *Server { return &Server{DB: db} })),
+		godi.WithUnusedCheck(func(*Server) {}),
+	))
+}
@@ -0,0 +1,28 @@
+package godi
+
+import (
+	"strconv"
+	"time"
+)
+
+// HookCriticalPath runs criticalPath over start hooks that ran one after another with the given durations
+// and returns the names on the path.
+func HookCriticalPath(durations ...time.Duration) []string {
+	spans := make([]traceSpan, 0, len(durations))
+	at := time.Now()
+	for i, duration := range durations {
+		spans = append(spans, traceSpan{
+			id:       "hook/" + strconv.Itoa(i) + "/start",
+			name:     "hook " + strconv.Itoa(i) + " start",
+			category: traceCategoryHook,
+			start:    at,
+			duration: duration,
+		})
+		at = at.Add(duration)
+	}
+	names := []string{}
+	for _, span := range criticalPath(spans) {
+		names = append(names, span.name)
+	}
+	return names
+}
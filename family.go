@@ -0,0 +1,119 @@
+package godi
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"go.uber.org/dig"
+)
+
+// Family is an injectable map of named instances registered with NewFamily, keyed by name.
+type Family[T any] map[string]T
+
+// NewFamily registers one named instance of T per name from a single constructor.
+// The constructor receives the instance name as its first argument, followed by regular dependencies:
+// func(name string, ...) T or func(name string, ...) (T, error).
+// Each instance is provided under WithName(name), and Family[T] collects all of them.
+func NewFamily[T any](names []string, constructor any, opts ...DependencyOption) Dependencies {
+	if err := validateFamily(reflect.TypeFor[T](), names, constructor); err != nil {
+		return CollectDependencies(Dependency{constructor: constructor, kind: dependencyKindProvide, err: err})
+	}
+
+	deps := make([]Dependency, 0, len(names)+1)
+	for _, name := range names {
+		memberOpts := append(append([]DependencyOption{}, opts...), WithName(name))
+		member := NewDependency(buildFamilyMember(name, constructor), memberOpts...)
+		member.origin = constructor
+		deps = append(deps, member)
+	}
+
+	collector := NewDependency(buildFamilyCollector[T](names))
+	collector.origin = constructor
+	deps = append(deps, collector)
+
+	return CollectDependencies(deps...)
+}
+
+func validateFamily(t reflect.Type, names []string, constructor any) error {
+	if len(names) == 0 {
+		return errors.New("family requires at least one name")
+	}
+	seen := map[string]bool{}
+	for _, name := range names {
+		if name == "" {
+			return errors.New("family name must not be empty")
+		}
+		if seen[name] {
+			return fmt.Errorf("duplicate family name %q", name)
+		}
+		seen[name] = true
+	}
+
+	if err := validateConstructor(constructor); err != nil {
+		return err
+	}
+	fnType := reflect.TypeOf(constructor)
+	if fnType.IsVariadic() {
+		return errors.New("family constructor must not be variadic")
+	}
+	if fnType.NumIn() < 1 || fnType.In(0).Kind() != reflect.String {
+		return fmt.Errorf("family constructor must accept the instance name as first argument, got %s", fnType)
+	}
+	if fnType.Out(0) != t {
+		return fmt.Errorf("family constructor must return %s, returns %s", t, fnType.Out(0))
+	}
+	return nil
+}
+
+// buildFamilyMember binds the instance name as the first constructor argument.
+func buildFamilyMember(name string, constructor any) any {
+	fnVal := reflect.ValueOf(constructor)
+	fnType := fnVal.Type()
+
+	in := make([]reflect.Type, 0, fnType.NumIn()-1)
+	for i := 1; i < fnType.NumIn(); i++ {
+		in = append(in, fnType.In(i))
+	}
+	out := make([]reflect.Type, 0, fnType.NumOut())
+	for i := range fnType.NumOut() {
+		out = append(out, fnType.Out(i))
+	}
+
+	nameVal := reflect.ValueOf(name).Convert(fnType.In(0))
+	wrapped := reflect.MakeFunc(reflect.FuncOf(in, out, false), func(args []reflect.Value) []reflect.Value {
+		return fnVal.Call(append([]reflect.Value{nameVal}, args...))
+	})
+	return wrapped.Interface()
+}
+
+func buildFamilyCollector[T any](names []string) any {
+	t := reflect.TypeFor[T]()
+	fields := make([]reflect.StructField, 0, len(names)+1)
+	fields = append(fields, reflect.StructField{
+		Name:      "In",
+		Type:      reflect.TypeOf(dig.In{}),
+		Anonymous: true,
+	})
+	for i, name := range names {
+		fields = append(fields, reflect.StructField{
+			Name: fmt.Sprintf("Item%d", i),
+			Type: t,
+			Tag:  reflect.StructTag(fmt.Sprintf(`name:"%s"`, name)),
+		})
+	}
+
+	inType := reflect.StructOf(fields)
+	familyType := reflect.TypeFor[Family[T]]()
+	fn := reflect.MakeFunc(reflect.FuncOf([]reflect.Type{inType}, []reflect.Type{familyType}, false),
+		func(args []reflect.Value) []reflect.Value {
+			family := make(Family[T], len(names))
+			for i, name := range names {
+				var v T
+				reflect.ValueOf(&v).Elem().Set(args[0].Field(i + 1))
+				family[name] = v
+			}
+			return []reflect.Value{reflect.ValueOf(family)}
+		})
+	return fn.Interface()
+}
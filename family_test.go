@@ -0,0 +1,76 @@
+package godi_test
+
+import (
+	"strings"
+	"testing"
+
+	"go.uber.org/dig"
+
+	"github.com/assurrussa/godi"
+)
+
+type familyDB struct {
+	region string
+	dsn    string
+}
+
+func TestNewFamilyProvidesNamedInstancesAndFamily(t *testing.T) {
+	t.Parallel()
+
+	cnt, err := godi.NewContainer(godi.WithDependencies(
+		godi.NewSingleDependency(func() string { return "dsn" }),
+		godi.NewFamily[*familyDB]([]string{"eu", "us"}, func(region, dsn string) (*familyDB, error) {
+			return &familyDB{region: region, dsn: dsn}, nil
+		}),
+	))
+	if err != nil {
+		t.Fatalf("NewContainer error: %v", err)
+	}
+	if err := cnt.Validate(); err != nil {
+		t.Fatalf("Validate error: %v", err)
+	}
+
+	var eu *familyDB
+	if err := cnt.Invoke(func(in struct {
+		dig.In
+		DB *familyDB `name:"eu"`
+	},
+	) {
+		eu = in.DB
+	}); err != nil {
+		t.Fatalf("Invoke error: %v", err)
+	}
+	if eu.region != "eu" || eu.dsn != "dsn" {
+		t.Fatalf("unexpected named instance: %+v", eu)
+	}
+
+	var family godi.Family[*familyDB]
+	if err := cnt.Invoke(func(f godi.Family[*familyDB]) { family = f }); err != nil {
+		t.Fatalf("Invoke error: %v", err)
+	}
+	if len(family) != 2 || family["us"].region != "us" || family["eu"] != eu {
+		t.Fatalf("unexpected family: %+v", family)
+	}
+
+	for _, p := range cnt.Graph().Providers {
+		if strings.HasPrefix(p.Constructor, "reflect.") {
+			t.Fatalf("expected family nodes to point at the user constructor, got %q", p.Constructor)
+		}
+	}
+}
+
+func TestNewFamilyRejectsInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]godi.Dependencies{
+		"no names":       godi.NewFamily[int](nil, func(string) int { return 0 }),
+		"duplicate name": godi.NewFamily[int]([]string{"a", "a"}, func(string) int { return 0 }),
+		"no name arg":    godi.NewFamily[int]([]string{"a"}, func() int { return 0 }),
+		"wrong type":     godi.NewFamily[int]([]string{"a"}, func(string) string { return "" }),
+	}
+	for name, deps := range cases {
+		if _, err := godi.NewContainer(godi.WithDependencies(deps)); err == nil {
+			t.Fatalf("%s: expected error, got nil", name)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Command godigen writes plain Go wiring code for a godi wiring function (see package
+// github.com/assurrussa/godi/godigen). It is meant to be run by go generate in the wiring package:
+//
+//	//go:generate go run github.com/assurrussa/godi/godigen/cmd/godigen -func Wiring
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/assurrussa/godi/godigen"
+)
+
+const defaultOutput = "godi_wiring_gen.go"
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stderr))
+}
+
+func run(args []string, stderr io.Writer) int {
+	flags := flag.NewFlagSet("godigen", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	dir := flags.String("dir", ".", "directory of the package that declares the wiring function")
+	fn := flags.String("func", "", "name of the wiring function (required)")
+	typeName := flags.String("type", "", "name of the generated struct (default <func>Container)")
+	out := flags.String("out", defaultOutput, "output file, relative to -dir")
+	tags := flags.String("tags", "", "comma-separated build tags used when loading packages")
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(stderr, "usage: godigen -func Wiring [-type Name] [-out file] [-dir path] [-tags list]\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *fn == "" || flags.NArg() > 0 {
+		flags.Usage()
+		return 2
+	}
+
+	cfg := godigen.Config{Dir: *dir, Func: *fn, Type: *typeName}
+	if *tags != "" {
+		cfg.Tags = strings.Split(*tags, ",")
+	}
+	src, err := godigen.Generate(cfg)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "godigen: %v\n", err)
+		return 1
+	}
+	//nolint:gosec // generated source files are world-readable like any other source file
+	if err := os.WriteFile(filepath.Join(*dir, *out), src, 0o644); err != nil {
+		_, _ = fmt.Fprintf(stderr, "godigen: %v\n", err)
+		return 1
+	}
+	return 0
+}
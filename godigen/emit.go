@@ -0,0 +1,268 @@
+package godigen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// emitter writes the generated file for the target package.
+type emitter struct {
+	target  *types.Package
+	imports map[string]string // path -> alias
+	aliases map[string]bool
+	body    bytes.Buffer
+}
+
+type outputField struct {
+	name  string
+	typ   types.Type
+	value ref
+}
+
+func newEmitter(target *types.Package) *emitter {
+	return &emitter{target: target, imports: map[string]string{}, aliases: map[string]bool{}}
+}
+
+func (e *emitter) qualifier(pkg *types.Package) string {
+	if pkg.Path() == e.target.Path() {
+		return ""
+	}
+	if alias, ok := e.imports[pkg.Path()]; ok {
+		return alias
+	}
+	alias := pkg.Name()
+	for i := 2; e.aliases[alias] || e.target.Scope().Lookup(alias) != nil; i++ {
+		alias = pkg.Name() + strconv.Itoa(i)
+	}
+	e.imports[pkg.Path()] = alias
+	e.aliases[alias] = true
+	return alias
+}
+
+func (e *emitter) typeString(t types.Type) string {
+	return types.TypeString(t, e.qualifier)
+}
+
+func (e *emitter) funcName(fn *types.Func) (string, error) {
+	if fn.Pkg().Path() == e.target.Path() {
+		return fn.Name(), nil
+	}
+	if !fn.Exported() {
+		return "", fmt.Errorf("constructor %s is not exported", fn.FullName())
+	}
+	return e.qualifier(fn.Pkg()) + "." + fn.Name(), nil
+}
+
+func (e *emitter) refString(r ref) string {
+	if r.field == "" {
+		return r.node.name
+	}
+	return r.node.name + "." + r.field
+}
+
+func (e *emitter) printf(format string, args ...any) {
+	_, _ = fmt.Fprintf(&e.body, format, args...)
+}
+
+func (e *emitter) emit(cfg Config, r *resolver, fields []outputField) ([]byte, error) {
+	e.printf("// %s holds the root-scope values built from %s.\n", cfg.Type, cfg.Func)
+	e.printf("type %s struct {\n", cfg.Type)
+	for _, field := range fields {
+		e.printf("\t%s %s\n", field.name, e.typeString(field.typ))
+	}
+	e.printf("}\n\n")
+
+	e.printf("// New%s calls every constructor and decorator registered by %s in dependency order.\n", cfg.Type, cfg.Func)
+	e.printf("func New%s() (*%s, error) {\n", cfg.Type, cfg.Type)
+	for _, n := range r.order {
+		if err := e.emitNode(n); err != nil {
+			return nil, err
+		}
+	}
+	e.printf("\treturn &%s{\n", cfg.Type)
+	for _, field := range fields {
+		e.printf("\t\t%s: %s,\n", field.name, e.refString(field.value))
+	}
+	e.printf("\t}, nil\n}\n")
+
+	var out bytes.Buffer
+	_, _ = fmt.Fprintf(&out, "// Code generated by godigen. DO NOT EDIT.\n\npackage %s\n\n", e.target.Name())
+	if len(e.imports) > 0 {
+		paths := make([]string, 0, len(e.imports))
+		for path := range e.imports {
+			paths = append(paths, path)
+		}
+		sort.Slice(paths, func(i, j int) bool {
+			if std := isStdPath(paths[i]); std != isStdPath(paths[j]) {
+				return std
+			}
+			return paths[i] < paths[j]
+		})
+		out.WriteString("import (\n")
+		for i, path := range paths {
+			if i > 0 && isStdPath(path) != isStdPath(paths[i-1]) {
+				out.WriteString("\n")
+			}
+			alias := e.imports[path]
+			if alias == path[strings.LastIndex(path, "/")+1:] {
+				_, _ = fmt.Fprintf(&out, "\t%q\n", path)
+			} else {
+				_, _ = fmt.Fprintf(&out, "\t%s %q\n", alias, path)
+			}
+		}
+		out.WriteString(")\n\n")
+	}
+	out.Write(e.body.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w\n%s", err, out.String())
+	}
+	return src, nil
+}
+
+// isStdPath reports whether an import path belongs to the standard library (no dot in the first element).
+func isStdPath(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
+
+func (e *emitter) emitNode(n *node) error {
+	if n.kind == nodeGroup {
+		elem := e.typeString(n.slot.typ)
+		e.printf("\t%s := make([]%s, 0, %d)\n", n.name, elem, len(n.members))
+		for _, m := range n.members {
+			spread := ""
+			if m.flatten {
+				spread = "..."
+			}
+			e.printf("\t%s = append(%s, %s%s)\n", n.name, n.name, e.refString(m.value), spread)
+		}
+		return nil
+	}
+
+	fn, err := e.funcName(n.entry.fn)
+	if err != nil {
+		return err
+	}
+	args := make([]string, 0, len(n.params))
+	for _, p := range n.params {
+		if p.value != nil {
+			args = append(args, e.refString(*p.value))
+			continue
+		}
+		fields := make([]string, 0, len(p.fields))
+		for _, f := range p.fields {
+			fields = append(fields, f.name+": "+e.refString(f.value))
+		}
+		args = append(args, e.typeString(p.in)+"{"+strings.Join(fields, ", ")+"}")
+	}
+	call := fn + "(" + strings.Join(args, ", ") + ")"
+
+	if n.entry.sig.Results().Len() == 2 {
+		e.printf("\t%s, err := %s\n", n.name, call)
+		e.printf("\tif err != nil {\n\t\treturn nil, fmt.Errorf(%q, err)\n\t}\n", n.entry.fn.FullName()+": %w")
+		e.imports["fmt"] = "fmt"
+		e.aliases["fmt"] = true
+	} else {
+		e.printf("\t%s := %s\n", n.name, call)
+	}
+	if !n.used {
+		e.printf("\t_ = %s\n", n.name)
+	}
+	return nil
+}
+
+// outputFields exposes every root-scope slot and group as an exported struct field.
+func outputFields(r *resolver) ([]outputField, error) {
+	fields := make([]outputField, 0)
+	seen := map[string]bool{}
+	names := map[string]int{}
+
+	for _, ent := range r.exposed {
+		if ent.kind == kindDecorate {
+			continue
+		}
+		for _, ps := range r.provided[ent] {
+			key := ps.slot.key()
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			value, ok, err := r.view(rootScope, ps.slot)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+			value.node.used = true
+
+			typ := ps.slot.typ
+			if ps.slot.group != "" {
+				typ = types.NewSlice(typ)
+			}
+			name := fieldName(ps.slot)
+			names[name]++
+			if names[name] > 1 {
+				name += strconv.Itoa(names[name])
+			}
+			fields = append(fields, outputField{name: name, typ: typ, value: value})
+		}
+	}
+	return fields, nil
+}
+
+func fieldName(s slot) string {
+	if s.group == runnableGroup {
+		return "Runnables"
+	}
+	if s.group != "" {
+		return exportName(s.group) + "Group"
+	}
+	return typeName(s.typ) + exportName(s.name)
+}
+
+func typeName(t types.Type) string {
+	switch tt := t.(type) {
+	case *types.Named:
+		return exportName(tt.Obj().Name())
+	case *types.Alias:
+		return exportName(tt.Obj().Name())
+	case *types.Pointer:
+		return typeName(tt.Elem())
+	case *types.Slice:
+		return typeName(tt.Elem()) + "s"
+	case *types.Map:
+		return typeName(tt.Elem()) + "Map"
+	case *types.Basic:
+		return exportName(tt.Name())
+	default:
+		return "Value"
+	}
+}
+
+// exportName turns a name such as "primary-db" into "PrimaryDb".
+func exportName(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
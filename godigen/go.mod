@@ -0,0 +1,17 @@
+module github.com/assurrussa/godi/godigen
+
+go 1.25.4
+
+require (
+	github.com/assurrussa/godi v0.0.0-20261019063611-3bac4f667dfc
+	go.uber.org/dig v1.19.0
+	golang.org/x/tools v0.38.0
+)
+
+require (
+	golang.org/x/mod v0.29.0 // indirect
+	golang.org/x/sync v0.17.0 // indirect
+)
+
+// Local development builds against the checkout; users of the tool get the required version above.
+replace github.com/assurrussa/godi => ..
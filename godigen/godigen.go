@@ -0,0 +1,131 @@
+// Package godigen generates plain Go wiring code from godi registrations.
+//
+// It reads a wiring function statically (go/packages, no code is executed) and emits a struct with
+// every root-scope value and a constructor that calls providers and decorators in dependency order,
+// so production binaries skip reflection-based resolution while tests keep using the dynamic godi.Container.
+// The generated code still imports godi for Lifecycle and Runnable, which keeps go.uber.org/dig linked
+// into the binary; it is only no longer used to build the graph.
+//
+// The wiring function must end with a return of godi.Dependencies or []godi.ContainerOption built from
+// godi calls (CollectDependencies, NewSingleDependency, NewDependency, Replace, Decorate, NewModule,
+// WithDependencies, WithModules, WithMatchings, WithDefaultLifecycle) or calls to argument-less helper
+// functions that follow the same rule. Constructors must be package-level functions; option arguments
+// must be constants. Resolution follows the container: Replace wins over Provide, private module providers
+// shadow root ones, the nearest decorator applies and matchings expose interfaces instead of concrete types.
+//
+// Unlike the container, the generated code constructs every winning provider eagerly, including
+// providers nothing depends on. godi.Lazy parameters, godi.NewFamily and decorators returning dig.Out
+// are not supported.
+package godigen
+
+import (
+	"errors"
+	"fmt"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Config selects the wiring function and names the generated code.
+type Config struct {
+	// Dir is the directory of the package that declares the wiring function; the generated file belongs there.
+	Dir string
+	// Func is the name of the wiring function.
+	Func string
+	// Type is the name of the generated struct; the constructor is named New<Type>.
+	Type string
+	// Tags are build tags used when loading packages.
+	Tags []string
+}
+
+// Generate loads the package in cfg.Dir and returns the formatted source of the generated file.
+func Generate(cfg Config) ([]byte, error) {
+	if cfg.Func == "" {
+		return nil, errors.New("wiring function name is required")
+	}
+	if cfg.Type == "" {
+		cfg.Type = cfg.Func + "Container"
+	}
+
+	loadCfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedTypesInfo |
+			packages.NeedSyntax | packages.NeedImports | packages.NeedDeps,
+		Dir: cfg.Dir,
+	}
+	if len(cfg.Tags) > 0 {
+		loadCfg.BuildFlags = []string{"-tags=" + strings.Join(cfg.Tags, ",")}
+	}
+	pkgs, err := packages.Load(loadCfg, ".")
+	if err != nil {
+		return nil, err
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, errors.New("failed to load packages")
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("expected one package in %s, got %d", cfg.Dir, len(pkgs))
+	}
+	target := pkgs[0]
+
+	fn, ok := target.Types.Scope().Lookup(cfg.Func).(*types.Func)
+	if !ok {
+		return nil, fmt.Errorf("function %s not found in %s", cfg.Func, target.PkgPath)
+	}
+
+	p, err := newParser(pkgs)
+	if err != nil {
+		return nil, err
+	}
+	decl, ok := p.decls[fn]
+	if !ok {
+		return nil, fmt.Errorf("declaration of %s is not available", cfg.Func)
+	}
+	w, err := p.parseWiring(decl)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := resolve(p, w)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := outputFields(r)
+	if err != nil {
+		return nil, err
+	}
+
+	roots := make([]*node, 0, len(r.nodes)+len(fields))
+	for _, e := range allEntries(w) {
+		if n, ok := r.nodes[e]; ok {
+			roots = append(roots, n)
+		}
+	}
+	for _, e := range allEntries(w) {
+		for _, n := range r.decNodes {
+			if n.entry == e {
+				roots = append(roots, n)
+			}
+		}
+	}
+	for _, field := range fields {
+		roots = append(roots, field.value.node)
+	}
+	if err := r.sort(roots); err != nil {
+		return nil, err
+	}
+	for i, n := range r.order {
+		n.name = "v" + strconv.Itoa(i)
+	}
+
+	return newEmitter(target.Types).emit(cfg, r, fields)
+}
+
+func allEntries(w *wiring) []*entry {
+	all := append([]*entry{}, w.root...)
+	for _, m := range w.modules {
+		all = append(all, m.entries...)
+	}
+	return all
+}
@@ -0,0 +1,87 @@
+package godigen_test
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/assurrussa/godi"
+	"github.com/assurrussa/godi/godigen"
+	"github.com/assurrussa/godi/godigen/testdata/app"
+)
+
+func TestGenerateMatchesCheckedInOutput(t *testing.T) {
+	t.Parallel()
+
+	src, err := godigen.Generate(godigen.Config{Dir: "testdata/app", Func: "Wiring"})
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	want, err := os.ReadFile("testdata/app/godi_wiring_gen.go")
+	if err != nil {
+		t.Fatalf("read golden: %v", err)
+	}
+	if !bytes.Equal(src, want) {
+		t.Fatalf("generated code differs from testdata/app/godi_wiring_gen.go (run go generate ./godigen/testdata/app):\n%s", src)
+	}
+}
+
+func TestGeneratedCodeMatchesContainer(t *testing.T) {
+	t.Parallel()
+
+	generated, err := app.NewWiringContainer()
+	if err != nil {
+		t.Fatalf("generated constructor: %v", err)
+	}
+
+	cnt, err := godi.NewContainer(app.Wiring()...)
+	if err != nil {
+		t.Fatalf("new container: %v", err)
+	}
+	var server *app.Server
+	if err := cnt.Invoke(func(s *app.Server) { server = s }); err != nil {
+		t.Fatalf("invoke: %v", err)
+	}
+
+	if server.Logger.Prefix != generated.Server.Logger.Prefix || server.Logger.Prefix != "[app]" {
+		t.Fatalf("logger prefix: container %q, generated %q", server.Logger.Prefix, generated.Server.Logger.Prefix)
+	}
+	if got, want := generated.Store.Get("k"), server.Store.Get("k"); got != want {
+		t.Fatalf("store: container %q, generated %q", want, got)
+	}
+	if len(generated.Server.Handlers) != len(server.Handlers) || len(generated.Runnables) != 1 {
+		t.Fatalf("groups: container %d handlers, generated %d handlers and %d runnables",
+			len(server.Handlers), len(generated.Server.Handlers), len(generated.Runnables))
+	}
+	if generated.Config.DSN != "memory://test" {
+		t.Fatalf("expected replaced config, got %+v", generated.Config)
+	}
+}
+
+func TestGenerateRejectsUnsupportedWiring(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		fn   string
+		want string
+	}{
+		{fn: "Cycle", want: "dependency cycle"},
+		{fn: "Missing", want: "missing dependency"},
+		{fn: "Lazy", want: "godi.Lazy parameters are not supported"},
+		{fn: "Closure", want: "constructor must be a package-level non-generic function"},
+		{fn: "Duplicate", want: "duplicate provider"},
+		{fn: "Dynamic", want: "expected a constant string"},
+		{fn: "Unknown", want: "function Unknown not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fn, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := godigen.Generate(godigen.Config{Dir: "testdata/invalid", Func: tt.fn})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
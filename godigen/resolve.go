@@ -0,0 +1,613 @@
+package godigen
+
+import (
+	"errors"
+	"fmt"
+	"go/types"
+	"reflect"
+	"strings"
+)
+
+const rootScope = ""
+
+type nodeKind int
+
+const (
+	nodeProvide nodeKind = iota
+	nodeDecorate
+	nodeGroup
+)
+
+// node is one statement of the generated constructor: a provider call, a decorator call or a group slice.
+type node struct {
+	kind    nodeKind
+	entry   *entry
+	scope   string
+	slot    slot
+	params  []param
+	members []member
+	deps    []*node
+	name    string
+	used    bool
+}
+
+// ref points to a value: a node result or a field of a dig.Out result.
+type ref struct {
+	node  *node
+	field string
+}
+
+// param is one constructor argument: a plain value or a dig.In struct literal.
+type param struct {
+	value  *ref
+	in     types.Type
+	fields []fieldValue
+}
+
+type fieldValue struct {
+	name  string
+	value ref
+}
+
+type member struct {
+	value   ref
+	flatten bool
+}
+
+// providedSlot is a slot filled by an entry, through its result or a dig.Out field.
+type providedSlot struct {
+	slot    slot
+	field   string
+	flatten bool
+}
+
+// scopeState mirrors resolvedScope: the winning provider per slot and group members in declaration order.
+type scopeState struct {
+	winners    map[string]*entry
+	groups     map[string][]*entry
+	decorators map[string]*entry
+}
+
+type resolver struct {
+	p        *parser
+	w        *wiring
+	provided map[*entry][]providedSlot
+	global   scopeState
+	modules  map[string]scopeState
+	nodes    map[*entry]*node
+	decNodes map[string]*node
+	groups   map[string]*node
+	exposed  []*entry
+	order    []*node
+}
+
+func resolve(p *parser, w *wiring) (*resolver, error) {
+	r := &resolver{
+		p:        p,
+		w:        w,
+		provided: map[*entry][]providedSlot{},
+		modules:  map[string]scopeState{},
+		nodes:    map[*entry]*node{},
+		decNodes: map[string]*node{},
+		groups:   map[string]*node{},
+	}
+
+	all := append([]*entry{}, w.root...)
+	for _, m := range w.modules {
+		all = append(all, m.entries...)
+	}
+	for _, e := range all {
+		if e.kind != kindDecorate {
+			e.matches = append(e.matches, r.matchedInterfaces(e)...)
+		}
+		slots, err := r.entrySlots(e)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", e.label(), err)
+		}
+		r.provided[e] = slots
+	}
+
+	globalEntries := append([]*entry{}, w.root...)
+	for _, m := range w.modules {
+		state, err := r.resolveScope(m.entries)
+		if err != nil {
+			return nil, fmt.Errorf("module %s: %w", m.name, err)
+		}
+		r.modules[m.name] = state
+		for _, e := range m.entries {
+			if e.kind != kindDecorate && !e.private && r.isWinner(e, state) {
+				globalEntries = append(globalEntries, e)
+			}
+		}
+	}
+	global, err := r.resolveScope(globalEntries)
+	if err != nil {
+		return nil, err
+	}
+	r.global = global
+	r.exposed = globalEntries
+
+	if err := r.buildNodes(globalEntries); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *resolver) resolveScope(entries []*entry) (scopeState, error) {
+	state := scopeState{winners: map[string]*entry{}, groups: map[string][]*entry{}, decorators: map[string]*entry{}}
+	provides := map[string]*entry{}
+	replaces := map[string]*entry{}
+
+	for _, e := range entries {
+		for _, ps := range r.provided[e] {
+			key := ps.slot.key()
+			switch {
+			case e.kind == kindDecorate:
+				if prev, ok := state.decorators[key]; ok {
+					return scopeState{}, fmt.Errorf("slot %s is decorated twice: %s and %s", ps.slot, prev.label(), e.label())
+				}
+				state.decorators[key] = e
+			case ps.slot.group != "":
+				if e.kind != kindProvide {
+					return scopeState{}, errors.New("replace/decorate is not supported for group dependency")
+				}
+				if !containsEntry(state.groups[key], e) {
+					state.groups[key] = append(state.groups[key], e)
+				}
+			case e.kind == kindProvide:
+				if _, ok := provides[key]; ok {
+					return scopeState{}, fmt.Errorf("duplicate provider for slot %s", ps.slot)
+				}
+				provides[key] = e
+			default:
+				if _, ok := replaces[key]; ok {
+					return scopeState{}, fmt.Errorf("duplicate replace for slot %s", ps.slot)
+				}
+				replaces[key] = e
+			}
+		}
+	}
+
+	for key, e := range provides {
+		state.winners[key] = e
+	}
+	for key, e := range replaces {
+		state.winners[key] = e
+	}
+	return state, nil
+}
+
+// isWinner mirrors isGlobalWinner: an entry is constructed only if it wins all of its non-group slots.
+func (r *resolver) isWinner(e *entry, state scopeState) bool {
+	for _, ps := range r.provided[e] {
+		if ps.slot.group != "" {
+			continue
+		}
+		if state.winners[ps.slot.key()] != e {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *resolver) buildNodes(globalEntries []*entry) error {
+	for _, e := range globalEntries {
+		if e.kind == kindDecorate || !r.isWinner(e, r.global) {
+			continue
+		}
+		if _, err := r.providerNode(e); err != nil {
+			return err
+		}
+	}
+	for _, m := range r.w.modules {
+		state := r.modules[m.name]
+		for _, e := range m.entries {
+			if e.kind == kindDecorate || !e.private || !r.isWinner(e, state) {
+				continue
+			}
+			if _, err := r.providerNode(e); err != nil {
+				return err
+			}
+		}
+	}
+	for _, e := range r.w.root {
+		if e.kind == kindDecorate {
+			if _, err := r.decoratorNode(rootScope, e); err != nil {
+				return err
+			}
+		}
+	}
+	for _, m := range r.w.modules {
+		for _, e := range m.entries {
+			if e.kind == kindDecorate {
+				if _, err := r.decoratorNode(m.name, e); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (r *resolver) providerNode(e *entry) (*node, error) {
+	if n, ok := r.nodes[e]; ok {
+		return n, nil
+	}
+	n := &node{kind: nodeProvide, entry: e, scope: e.module}
+	r.nodes[e] = n
+
+	params, deps, err := r.resolveParams(e, e.module, nil)
+	if err != nil {
+		return nil, err
+	}
+	n.params = params
+	n.deps = deps
+	return n, nil
+}
+
+func (r *resolver) decoratorNode(scope string, e *entry) (*node, error) {
+	ps := r.provided[e][0]
+	key := scope + "|" + ps.slot.key()
+	if n, ok := r.decNodes[key]; ok {
+		return n, nil
+	}
+	if _, ok := r.raw(scope, ps.slot); !ok {
+		return nil, fmt.Errorf("%s: cannot decorate slot %s: no provider", e.label(), ps.slot)
+	}
+
+	n := &node{kind: nodeDecorate, entry: e, scope: scope, slot: ps.slot}
+	r.decNodes[key] = n
+
+	params, deps, err := r.resolveParams(e, scope, &ps.slot)
+	if err != nil {
+		return nil, err
+	}
+	n.params = params
+	n.deps = deps
+	return n, nil
+}
+
+// raw returns the undecorated value of a slot as seen from a scope:
+// a private module provider shadows the global winner.
+func (r *resolver) raw(scope string, s slot) (*entry, bool) {
+	key := s.key()
+	if scope != rootScope {
+		if e, ok := r.modules[scope].winners[key]; ok && e.private {
+			return e, true
+		}
+	}
+	e, ok := r.global.winners[key]
+	return e, ok
+}
+
+func (r *resolver) rawRef(scope string, s slot) (ref, bool, error) {
+	e, ok := r.raw(scope, s)
+	if !ok {
+		return ref{}, false, nil
+	}
+	n, err := r.providerNode(e)
+	if err != nil {
+		return ref{}, false, err
+	}
+	for _, ps := range r.provided[e] {
+		if ps.slot.key() == s.key() {
+			return ref{node: n, field: ps.field}, true, nil
+		}
+	}
+	return ref{node: n}, true, nil
+}
+
+// view returns the value a consumer in scope receives: the nearest decorator wins, as in dig.
+func (r *resolver) view(scope string, s slot) (ref, bool, error) {
+	if s.group != "" {
+		return r.groupRef(scope, s)
+	}
+	key := s.key()
+	if scope != rootScope {
+		if e, ok := r.modules[scope].decorators[key]; ok {
+			n, err := r.decoratorNode(scope, e)
+			return ref{node: n}, err == nil, err
+		}
+	}
+	if e, ok := r.global.decorators[key]; ok {
+		n, err := r.decoratorNode(rootScope, e)
+		return ref{node: n}, err == nil, err
+	}
+	return r.rawRef(scope, s)
+}
+
+func (r *resolver) groupRef(scope string, s slot) (ref, bool, error) {
+	key := scope + "|" + s.key()
+	if n, ok := r.groups[key]; ok {
+		return ref{node: n}, true, nil
+	}
+
+	entries := append([]*entry{}, r.global.groups[s.key()]...)
+	if scope != rootScope {
+		for _, e := range r.modules[scope].groups[s.key()] {
+			if e.private {
+				entries = append(entries, e)
+			}
+		}
+	}
+
+	n := &node{kind: nodeGroup, scope: scope, slot: s}
+	r.groups[key] = n
+	for _, e := range entries {
+		member, err := r.providerNode(e)
+		if err != nil {
+			return ref{}, false, err
+		}
+		for _, ps := range r.provided[e] {
+			if ps.slot.key() == s.key() {
+				n.members = append(n.members, memberOf(member, ps))
+			}
+		}
+		n.deps = append(n.deps, member)
+		member.used = true
+	}
+	return ref{node: n}, true, nil
+}
+
+func memberOf(n *node, ps providedSlot) member {
+	return member{value: ref{node: n, field: ps.field}, flatten: ps.flatten}
+}
+
+// resolveParams resolves constructor arguments in scope. For decorators, decorated is the slot whose
+// undecorated value is passed in.
+func (r *resolver) resolveParams(e *entry, scope string, decorated *slot) ([]param, []*node, error) {
+	params := make([]param, 0, e.sig.Params().Len())
+	deps := make([]*node, 0)
+
+	lookup := func(s slot) (ref, bool, error) {
+		if decorated != nil && s.key() == decorated.key() {
+			return r.rawRef(scope, s)
+		}
+		return r.view(scope, s)
+	}
+
+	for i := range e.sig.Params().Len() {
+		t := e.sig.Params().At(i).Type()
+		if r.isLazy(t) {
+			return nil, nil, fmt.Errorf("%s: godi.Lazy parameters are not supported by the generator", e.label())
+		}
+
+		if st, ok := digStruct(t, "In"); ok {
+			p := param{in: t}
+			for j := range st.NumFields() {
+				field := st.Field(j)
+				if field.Embedded() || !field.Exported() {
+					continue
+				}
+				tag := reflect.StructTag(st.Tag(j))
+				s := slot{typ: field.Type(), name: tag.Get("name")}
+				if group, _ := parseGroupTag(tag.Get("group")); group != "" {
+					slice, ok := field.Type().(*types.Slice)
+					if !ok {
+						return nil, nil, fmt.Errorf("%s: group field %s must be a slice", e.label(), field.Name())
+					}
+					s = slot{typ: slice.Elem(), group: group}
+				}
+				value, ok, err := lookup(s)
+				if err != nil {
+					return nil, nil, err
+				}
+				if !ok {
+					if tag.Get("optional") == "true" {
+						continue
+					}
+					return nil, nil, fmt.Errorf("%s: missing dependency %s", e.label(), s)
+				}
+				p.fields = append(p.fields, fieldValue{name: field.Name(), value: value})
+				deps = append(deps, value.node)
+				value.node.used = true
+			}
+			params = append(params, p)
+			continue
+		}
+
+		value, ok, err := lookup(slot{typ: t})
+		if err != nil {
+			return nil, nil, err
+		}
+		if !ok {
+			return nil, nil, fmt.Errorf("%s: missing dependency %s", e.label(), slot{typ: t})
+		}
+		value.node.used = true
+		params = append(params, param{value: &value})
+		deps = append(deps, value.node)
+	}
+	return params, deps, nil
+}
+
+// entrySlots mirrors dependencySlots and decoratorSlots.
+func (r *resolver) entrySlots(e *entry) ([]providedSlot, error) {
+	out := e.sig.Results().At(0).Type()
+
+	if e.kind == kindDecorate {
+		if e.name != "" || e.group != "" || len(e.matches) > 0 {
+			return nil, errors.New("decorate does not support WithName/WithGroup/WithMatch")
+		}
+		if _, ok := digStruct(out, "Out"); ok {
+			return nil, errors.New("decorators returning dig.Out are not supported by the generator")
+		}
+		return []providedSlot{{slot: slot{typ: out}}}, nil
+	}
+
+	if st, ok := digStruct(out, "Out"); ok {
+		if e.name != "" || e.group != "" || len(e.matches) > 0 {
+			return nil, errors.New("dig.Out cannot be combined with WithName/WithGroup/WithMatch")
+		}
+		slots := make([]providedSlot, 0, st.NumFields())
+		for i := range st.NumFields() {
+			field := st.Field(i)
+			if field.Embedded() || !field.Exported() {
+				continue
+			}
+			tag := reflect.StructTag(st.Tag(i))
+			ps := providedSlot{slot: slot{typ: field.Type(), name: tag.Get("name")}, field: field.Name()}
+			if group, flatten := parseGroupTag(tag.Get("group")); group != "" {
+				ps.slot = slot{typ: field.Type(), group: group}
+				ps.flatten = flatten
+				if slice, ok := field.Type().(*types.Slice); ok && flatten {
+					ps.slot.typ = slice.Elem()
+				}
+			}
+			slots = append(slots, ps)
+		}
+		return slots, nil
+	}
+
+	group, flatten := e.group, e.flatten
+	if types.Identical(out, r.p.runnable) {
+		if group != "" {
+			return nil, errors.New("invalid dependency options: Runnable cannot be used with WithGroup")
+		}
+		group, flatten = runnableGroup, false
+	}
+	if e.name != "" && group != "" {
+		return nil, errors.New("invalid dependency options: WithName cannot be used with WithGroup or Runnable")
+	}
+
+	exposed := []types.Type{out}
+	if len(e.matches) > 0 {
+		exposed = uniqueTypes(e.matches)
+		for _, iface := range exposed {
+			if !types.Implements(out, iface.Underlying().(*types.Interface)) {
+				return nil, fmt.Errorf("%s does not implement %s", out, iface)
+			}
+		}
+	}
+
+	slots := make([]providedSlot, 0, len(exposed))
+	for _, t := range exposed {
+		ps := providedSlot{slot: slot{typ: t, name: e.name, group: group}, flatten: flatten}
+		if slice, ok := t.(*types.Slice); ok && flatten {
+			ps.slot.typ = slice.Elem()
+		}
+		slots = append(slots, ps)
+	}
+	return slots, nil
+}
+
+// matchedInterfaces mirrors applyMatchings for container-level WithMatchings.
+func (r *resolver) matchedInterfaces(e *entry) []types.Type {
+	out := e.sig.Results().At(0).Type()
+	result := make([]types.Type, 0)
+	for _, m := range r.w.matchings {
+		if m.origin == nil {
+			if types.Implements(out, m.interfaces[0].Underlying().(*types.Interface)) {
+				result = append(result, m.interfaces[0])
+			}
+			continue
+		}
+		ptr, isPtr := out.(*types.Pointer)
+		if types.Identical(out, m.origin) || (isPtr && types.Identical(ptr.Elem(), m.origin)) {
+			result = append(result, m.interfaces...)
+		}
+	}
+	return result
+}
+
+func (r *resolver) isLazy(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == godiPath && named.Obj().Name() == "Lazy"
+}
+
+// sort orders nodes so every node follows its dependencies, keeping declaration order otherwise.
+func (r *resolver) sort(roots []*node) error {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := map[*node]int{}
+	stack := make([]*node, 0)
+
+	var visit func(n *node) error
+	visit = func(n *node) error {
+		switch state[n] {
+		case done:
+			return nil
+		case visiting:
+			labels := make([]string, 0)
+			for i := len(stack) - 1; i >= 0; i-- {
+				labels = append(labels, stack[i].label())
+				if stack[i] == n {
+					break
+				}
+			}
+			return fmt.Errorf("dependency cycle: %s", strings.Join(labels, " <- "))
+		}
+		state[n] = visiting
+		stack = append(stack, n)
+		for _, dep := range n.deps {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[n] = done
+		r.order = append(r.order, n)
+		return nil
+	}
+
+	for _, n := range roots {
+		if err := visit(n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *node) label() string {
+	if n.kind == nodeGroup {
+		return "group " + n.slot.String()
+	}
+	return n.entry.fn.FullName()
+}
+
+// digStruct reports whether t (or *t) is a struct embedding dig.In or dig.Out.
+func digStruct(t types.Type, embedded string) (*types.Struct, bool) {
+	if ptr, ok := t.(*types.Pointer); ok && embedded == "Out" {
+		t = ptr.Elem()
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return nil, false
+	}
+	for i := range st.NumFields() {
+		field := st.Field(i)
+		named, ok := field.Type().(*types.Named)
+		if field.Embedded() && ok && named.Obj().Pkg() != nil &&
+			named.Obj().Pkg().Path() == digPath && named.Obj().Name() == embedded {
+			return st, true
+		}
+	}
+	return nil, false
+}
+
+func uniqueTypes(in []types.Type) []types.Type {
+	out := make([]types.Type, 0, len(in))
+	for _, t := range in {
+		dup := false
+		for _, seen := range out {
+			if types.Identical(seen, t) {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+func containsEntry(entries []*entry, e *entry) bool {
+	for _, item := range entries {
+		if item == e {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,508 @@
+package godigen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+const (
+	godiPath      = "github.com/assurrussa/godi"
+	digPath       = "go.uber.org/dig"
+	runnableGroup = "goshared_di_runnable"
+)
+
+type entryKind int
+
+const (
+	kindProvide entryKind = iota
+	kindReplace
+	kindDecorate
+)
+
+// entry is a statically parsed NewDependency, Replace or Decorate call.
+type entry struct {
+	kind    entryKind
+	fn      *types.Func
+	sig     *types.Signature
+	name    string
+	group   string
+	flatten bool
+	private bool
+	matches []types.Type
+	module  string
+	pos     token.Position
+}
+
+func (e *entry) label() string {
+	return fmt.Sprintf("%s (%s)", e.fn.FullName(), e.pos)
+}
+
+// matching is a container-level WithMatchings argument.
+type matching struct {
+	origin     types.Type // nil for a plain pointer-to-interface matching
+	interfaces []types.Type
+}
+
+type module struct {
+	name    string
+	entries []*entry
+}
+
+// wiring is everything a wiring function registers.
+type wiring struct {
+	root      []*entry
+	modules   []*module
+	matchings []matching
+}
+
+// slot mirrors the container's (type, name, group) resolution key.
+type slot struct {
+	typ   types.Type
+	name  string
+	group string
+}
+
+func (s slot) key() string {
+	return types.TypeString(s.typ, nil) + "|" + s.name + "|" + s.group
+}
+
+func (s slot) String() string {
+	label := types.TypeString(s.typ, nil)
+	if s.name != "" {
+		label += fmt.Sprintf("[name=%s]", s.name)
+	}
+	if s.group != "" {
+		label += fmt.Sprintf("[group=%s]", s.group)
+	}
+	return label
+}
+
+// parser evaluates the wiring function's return expression, following calls into helper functions.
+type parser struct {
+	decls    map[*types.Func]funcDecl
+	godi     *types.Package
+	runnable types.Type
+}
+
+type funcDecl struct {
+	decl *ast.FuncDecl
+	pkg  *packages.Package
+}
+
+func newParser(pkgs []*packages.Package) (*parser, error) {
+	p := &parser{decls: map[*types.Func]funcDecl{}}
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		if pkg.PkgPath == godiPath {
+			p.godi = pkg.Types
+		}
+		for _, file := range pkg.Syntax {
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Body == nil {
+					continue
+				}
+				if obj, ok := pkg.TypesInfo.Defs[fn.Name].(*types.Func); ok {
+					p.decls[obj] = funcDecl{decl: fn, pkg: pkg}
+				}
+			}
+		}
+	})
+	if p.godi == nil {
+		return nil, fmt.Errorf("package %s is not imported", godiPath)
+	}
+	p.runnable = p.godi.Scope().Lookup("Runnable").Type()
+	return p, nil
+}
+
+func (p *parser) parseWiring(decl funcDecl) (*wiring, error) {
+	expr, pkg, err := p.returnExpr(decl)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &wiring{}
+	switch {
+	case p.isGodiType(pkg.TypesInfo.TypeOf(expr), "Dependencies"):
+		w.root, err = p.dependencies(expr, pkg, "")
+	case isSliceOf(pkg.TypesInfo.TypeOf(expr), p.godiType("ContainerOption")):
+		err = p.containerOptions(expr, pkg, w)
+	default:
+		err = fmt.Errorf("%s must return godi.Dependencies or []godi.ContainerOption", decl.decl.Name.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// returnExpr requires the function body to end with a single-value return statement.
+func (p *parser) returnExpr(decl funcDecl) (ast.Expr, *packages.Package, error) {
+	body := decl.decl.Body.List
+	if len(body) == 0 {
+		return nil, nil, fmt.Errorf("%s: empty function body", decl.decl.Name.Name)
+	}
+	ret, ok := body[len(body)-1].(*ast.ReturnStmt)
+	if !ok || len(ret.Results) != 1 {
+		return nil, nil, fmt.Errorf("%s: body must end with a single-value return statement", decl.decl.Name.Name)
+	}
+	return ast.Unparen(ret.Results[0]), decl.pkg, nil
+}
+
+// follow resolves a call to a helper function declared in the loaded packages.
+func (p *parser) follow(call *ast.CallExpr, pkg *packages.Package) (ast.Expr, *packages.Package, error) {
+	fn := calleeFunc(pkg.TypesInfo, call)
+	if fn == nil {
+		return nil, nil, p.errorf(pkg, call, "unsupported expression")
+	}
+	decl, ok := p.decls[fn]
+	if !ok {
+		return nil, nil, p.errorf(pkg, call, "declaration of %s is not available", fn.FullName())
+	}
+	if len(call.Args) > 0 {
+		return nil, nil, p.errorf(pkg, call, "helper %s must not take arguments", fn.Name())
+	}
+	return p.returnExpr(decl)
+}
+
+func (p *parser) containerOptions(expr ast.Expr, pkg *packages.Package, w *wiring) error {
+	switch e := expr.(type) {
+	case *ast.CompositeLit:
+		for _, elt := range e.Elts {
+			if err := p.containerOption(ast.Unparen(elt), pkg, w); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *ast.CallExpr:
+		next, nextPkg, err := p.follow(e, pkg)
+		if err != nil {
+			return err
+		}
+		return p.containerOptions(next, nextPkg, w)
+	default:
+		return p.errorf(pkg, expr, "unsupported container options expression")
+	}
+}
+
+func (p *parser) containerOption(expr ast.Expr, pkg *packages.Package, w *wiring) error {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return p.errorf(pkg, expr, "unsupported container option")
+	}
+	if call.Ellipsis.IsValid() {
+		return p.errorf(pkg, call, "spread arguments are not supported")
+	}
+
+	switch p.godiFuncName(pkg, call) {
+	case "WithDependencies":
+		for _, arg := range call.Args {
+			entries, err := p.dependencies(ast.Unparen(arg), pkg, "")
+			if err != nil {
+				return err
+			}
+			w.root = append(w.root, entries...)
+		}
+	case "WithModules":
+		for _, arg := range call.Args {
+			m, err := p.module(ast.Unparen(arg), pkg)
+			if err != nil {
+				return err
+			}
+			w.modules = append(w.modules, m)
+		}
+	case "WithMatchings":
+		for _, arg := range call.Args {
+			m, err := p.matching(ast.Unparen(arg), pkg)
+			if err != nil {
+				return err
+			}
+			w.matchings = append(w.matchings, m)
+		}
+	case "WithDefaultLifecycle":
+		fn, ok := p.godi.Scope().Lookup("NewLifecycle").(*types.Func)
+		if !ok {
+			return p.errorf(pkg, call, "godi.NewLifecycle is not available")
+		}
+		sig, _ := fn.Type().(*types.Signature)
+		w.root = append(w.root, &entry{kind: kindProvide, fn: fn, sig: sig, pos: pkg.Fset.Position(call.Pos())})
+	case "WithUnusedCheck":
+		// Diagnostics only; it has no effect on construction.
+	default:
+		next, nextPkg, err := p.follow(call, pkg)
+		if err != nil {
+			return err
+		}
+		return p.containerOption(next, nextPkg, w)
+	}
+	return nil
+}
+
+func (p *parser) module(expr ast.Expr, pkg *packages.Package) (*module, error) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return nil, p.errorf(pkg, expr, "modules must be created with godi.NewModule")
+	}
+	if p.godiFuncName(pkg, call) != "NewModule" {
+		next, nextPkg, err := p.follow(call, pkg)
+		if err != nil {
+			return nil, err
+		}
+		return p.module(next, nextPkg)
+	}
+
+	name, err := p.stringConst(pkg, call.Args[0])
+	if err != nil {
+		return nil, err
+	}
+	entries, err := p.dependencies(ast.Unparen(call.Args[1]), pkg, name)
+	if err != nil {
+		return nil, err
+	}
+	return &module{name: name, entries: entries}, nil
+}
+
+func (p *parser) dependencies(expr ast.Expr, pkg *packages.Package, moduleName string) ([]*entry, error) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return nil, p.errorf(pkg, expr, "unsupported dependencies expression")
+	}
+	if call.Ellipsis.IsValid() {
+		return nil, p.errorf(pkg, call, "spread arguments are not supported")
+	}
+
+	switch p.godiFuncName(pkg, call) {
+	case "CollectDependencies":
+		entries := make([]*entry, 0, len(call.Args))
+		for _, arg := range call.Args {
+			e, err := p.dependency(ast.Unparen(arg), pkg, moduleName)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, e)
+		}
+		return entries, nil
+	case "NewSingleDependency":
+		e, err := p.newEntry(call, pkg, kindProvide, moduleName)
+		if err != nil {
+			return nil, err
+		}
+		return []*entry{e}, nil
+	default:
+		next, nextPkg, err := p.follow(call, pkg)
+		if err != nil {
+			return nil, err
+		}
+		return p.dependencies(next, nextPkg, moduleName)
+	}
+}
+
+func (p *parser) dependency(expr ast.Expr, pkg *packages.Package, moduleName string) (*entry, error) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return nil, p.errorf(pkg, expr, "unsupported dependency expression")
+	}
+
+	switch p.godiFuncName(pkg, call) {
+	case "NewDependency":
+		return p.newEntry(call, pkg, kindProvide, moduleName)
+	case "Replace":
+		return p.newEntry(call, pkg, kindReplace, moduleName)
+	case "Decorate":
+		return p.newEntry(call, pkg, kindDecorate, moduleName)
+	case "NewFamily":
+		return nil, p.errorf(pkg, call, "godi.NewFamily is not supported by the generator")
+	default:
+		next, nextPkg, err := p.follow(call, pkg)
+		if err != nil {
+			return nil, err
+		}
+		return p.dependency(next, nextPkg, moduleName)
+	}
+}
+
+func (p *parser) newEntry(call *ast.CallExpr, pkg *packages.Package, kind entryKind, moduleName string) (*entry, error) {
+	if call.Ellipsis.IsValid() {
+		return nil, p.errorf(pkg, call, "spread arguments are not supported")
+	}
+	if len(call.Args) == 0 {
+		return nil, p.errorf(pkg, call, "missing constructor")
+	}
+
+	fn, err := p.constructor(ast.Unparen(call.Args[0]), pkg)
+	if err != nil {
+		return nil, err
+	}
+	sig, _ := fn.Type().(*types.Signature)
+	e := &entry{kind: kind, fn: fn, sig: sig, module: moduleName, pos: pkg.Fset.Position(call.Args[0].Pos())}
+	if err := validateSignature(sig); err != nil {
+		return nil, p.errorf(pkg, call.Args[0], "%s: %v", fn.Name(), err)
+	}
+
+	for _, arg := range call.Args[1:] {
+		if err := p.option(ast.Unparen(arg), pkg, e); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+func (p *parser) option(expr ast.Expr, pkg *packages.Package, e *entry) error {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return p.errorf(pkg, expr, "unsupported dependency option")
+	}
+
+	var err error
+	switch p.godiFuncName(pkg, call) {
+	case "WithName":
+		e.name, err = p.stringConst(pkg, call.Args[0])
+	case "WithGroup":
+		var group string
+		group, err = p.stringConst(pkg, call.Args[0])
+		e.group, e.flatten = parseGroupTag(group)
+	case "Private":
+		e.private = true
+	case "WithMatch":
+		var iface types.Type
+		iface, err = p.interfacePointer(pkg, call.Args[0])
+		e.matches = append(e.matches, iface)
+	case "WithKey":
+		// Diagnostics only.
+	default:
+		return p.errorf(pkg, call, "unsupported dependency option")
+	}
+	return err
+}
+
+func (p *parser) matching(expr ast.Expr, pkg *packages.Package) (matching, error) {
+	if call, ok := expr.(*ast.CallExpr); ok && p.godiFuncName(pkg, call) == "NewMatching" {
+		if call.Ellipsis.IsValid() {
+			return matching{}, p.errorf(pkg, call, "spread arguments are not supported")
+		}
+		ptr, ok := pkg.TypesInfo.TypeOf(call.Args[0]).(*types.Pointer)
+		if !ok {
+			return matching{}, p.errorf(pkg, call.Args[0], "origin must be a pointer")
+		}
+		m := matching{origin: ptr.Elem()}
+		for _, arg := range call.Args[1:] {
+			iface, err := p.interfacePointer(pkg, arg)
+			if err != nil {
+				return matching{}, err
+			}
+			m.interfaces = append(m.interfaces, iface)
+		}
+		return m, nil
+	}
+
+	iface, err := p.interfacePointer(pkg, expr)
+	if err != nil {
+		return matching{}, err
+	}
+	return matching{interfaces: []types.Type{iface}}, nil
+}
+
+func (p *parser) constructor(expr ast.Expr, pkg *packages.Package) (*types.Func, error) {
+	var ident *ast.Ident
+	switch e := expr.(type) {
+	case *ast.Ident:
+		ident = e
+	case *ast.SelectorExpr:
+		ident = e.Sel
+	}
+	if ident != nil {
+		if fn, ok := pkg.TypesInfo.Uses[ident].(*types.Func); ok {
+			sig, _ := fn.Type().(*types.Signature)
+			if sig.Recv() == nil && sig.TypeParams() == nil {
+				return fn, nil
+			}
+		}
+	}
+	return nil, p.errorf(pkg, expr, "constructor must be a package-level non-generic function")
+}
+
+func (p *parser) interfacePointer(pkg *packages.Package, expr ast.Expr) (types.Type, error) {
+	if ptr, ok := pkg.TypesInfo.TypeOf(expr).(*types.Pointer); ok && types.IsInterface(ptr.Elem()) {
+		return ptr.Elem(), nil
+	}
+	return nil, p.errorf(pkg, expr, "matching interface must be pointer to interface")
+}
+
+func (p *parser) stringConst(pkg *packages.Package, expr ast.Expr) (string, error) {
+	tv, ok := pkg.TypesInfo.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", p.errorf(pkg, expr, "expected a constant string")
+	}
+	return constant.StringVal(tv.Value), nil
+}
+
+// godiFuncName returns the name of the called godi function, or "" for any other callee.
+func (p *parser) godiFuncName(pkg *packages.Package, call *ast.CallExpr) string {
+	fn := calleeFunc(pkg.TypesInfo, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != godiPath {
+		return ""
+	}
+	return fn.Name()
+}
+
+func (p *parser) godiType(name string) types.Type {
+	return p.godi.Scope().Lookup(name).Type()
+}
+
+func (p *parser) isGodiType(t types.Type, name string) bool {
+	return t != nil && types.Identical(t, p.godiType(name))
+}
+
+func (p *parser) errorf(pkg *packages.Package, node ast.Node, format string, args ...any) error {
+	return fmt.Errorf("%s: %s", pkg.Fset.Position(node.Pos()), fmt.Sprintf(format, args...))
+}
+
+func calleeFunc(info *types.Info, call *ast.CallExpr) *types.Func {
+	var ident *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		ident = fun
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	default:
+		return nil
+	}
+	fn, _ := info.Uses[ident].(*types.Func)
+	return fn
+}
+
+func isSliceOf(t, elem types.Type) bool {
+	slice, ok := t.(*types.Slice)
+	return ok && types.Identical(slice.Elem(), elem)
+}
+
+// validateSignature mirrors the container's constructor rules: func(...) T or func(...) (T, error).
+func validateSignature(sig *types.Signature) error {
+	errType := types.Universe.Lookup("error").Type()
+	results := sig.Results()
+	switch {
+	case results.Len() == 1 && !types.Identical(results.At(0).Type(), errType):
+		return nil
+	case results.Len() == 2 && !types.Identical(results.At(0).Type(), errType) &&
+		types.Identical(results.At(1).Type(), errType):
+		return nil
+	default:
+		return fmt.Errorf("constructor must return T or (T, error), got %s", results)
+	}
+}
+
+func parseGroupTag(tag string) (string, bool) {
+	parts := strings.Split(tag, ",")
+	flatten := false
+	for _, part := range parts[1:] {
+		if strings.TrimSpace(part) == "flatten" {
+			flatten = true
+		}
+	}
+	return parts[0], flatten
+}
@@ -0,0 +1,119 @@
+// Package app is a sample wiring used by the godigen golden test.
+//
+//go:generate go run ../../cmd/godigen -func Wiring
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.uber.org/dig"
+
+	"github.com/assurrussa/godi"
+)
+
+type Config struct {
+	DSN  string
+	Name string
+}
+
+type Logger struct {
+	Prefix string
+}
+
+type Store interface {
+	Get(key string) string
+}
+
+type memoryStore struct {
+	dsn string
+}
+
+func (s *memoryStore) Get(key string) string { return s.dsn + "/" + key }
+
+type Handler struct {
+	Route string
+}
+
+type Server struct {
+	Name     string
+	Store    Store
+	Logger   *Logger
+	Handlers []Handler
+}
+
+type serverParams struct {
+	dig.In
+
+	Config   Config
+	Store    Store
+	Logger   *Logger
+	Handlers []Handler        `group:"handlers"`
+	Missing  *strings.Builder `optional:"true"`
+}
+
+func NewConfig() Config { return Config{DSN: "memory://", Name: "app"} }
+
+func NewTestConfig() Config { return Config{DSN: "memory://test", Name: "app"} }
+
+func NewLogger(cfg Config) *Logger { return &Logger{Prefix: cfg.Name} }
+
+func DecorateLogger(l *Logger) *Logger { return &Logger{Prefix: "[" + l.Prefix + "]"} }
+
+func NewStoreConfig() Config { return Config{DSN: "memory://store"} }
+
+func NewStore(cfg Config) (*memoryStore, error) {
+	if cfg.DSN == "" {
+		return nil, errors.New("empty dsn")
+	}
+	return &memoryStore{dsn: cfg.DSN}, nil
+}
+
+func NewUsersHandler() Handler { return Handler{Route: "/users"} }
+
+func NewHealthHandler() Handler { return Handler{Route: "/health"} }
+
+func NewServer(p serverParams) *Server {
+	return &Server{Name: p.Config.Name, Store: p.Store, Logger: p.Logger, Handlers: p.Handlers}
+}
+
+func NewServerRunnable(s *Server) godi.Runnable {
+	return godi.Runnable{
+		OnStart: func(context.Context) error { return nil },
+		OnStop:  func(context.Context) error { return nil },
+	}
+}
+
+func storageModule() godi.Module {
+	return godi.NewModule("storage", godi.CollectDependencies(
+		godi.NewDependency(NewStoreConfig, godi.Private()),
+		godi.NewDependency(NewStore, godi.WithMatch((*Store)(nil))),
+	))
+}
+
+func handlers() godi.Dependencies {
+	return godi.CollectDependencies(
+		godi.NewDependency(NewUsersHandler, godi.WithGroup("handlers")),
+		godi.NewDependency(NewHealthHandler, godi.WithGroup("handlers")),
+	)
+}
+
+// Wiring is the input of the generator; see wiring_gen.go for the output.
+func Wiring() []godi.ContainerOption {
+	return []godi.ContainerOption{
+		godi.WithDefaultLifecycle(),
+		godi.WithModules(storageModule()),
+		godi.WithDependencies(
+			godi.CollectDependencies(
+				godi.NewDependency(NewConfig),
+				godi.Replace(NewTestConfig),
+				godi.NewDependency(NewLogger),
+				godi.Decorate(DecorateLogger),
+				godi.NewDependency(NewServer),
+				godi.NewDependency(NewServerRunnable),
+			),
+			handlers(),
+		),
+	}
+}
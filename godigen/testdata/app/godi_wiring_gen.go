@@ -0,0 +1,51 @@
+// Code generated by godigen. DO NOT EDIT.
+
+package app
+
+import (
+	"fmt"
+
+	"github.com/assurrussa/godi"
+)
+
+// WiringContainer holds the root-scope values built from Wiring.
+type WiringContainer struct {
+	Lifecycle     *godi.Lifecycle
+	Config        Config
+	Logger        *Logger
+	Server        *Server
+	Runnables     []godi.Runnable
+	HandlersGroup []Handler
+	Store         Store
+}
+
+// NewWiringContainer calls every constructor and decorator registered by Wiring in dependency order.
+func NewWiringContainer() (*WiringContainer, error) {
+	v0 := godi.NewLifecycle()
+	v1 := NewTestConfig()
+	v2 := NewLogger(v1)
+	v3 := NewStoreConfig()
+	v4, err := NewStore(v3)
+	if err != nil {
+		return nil, fmt.Errorf("github.com/assurrussa/godi/godigen/testdata/app.NewStore: %w", err)
+	}
+	v5 := DecorateLogger(v2)
+	v6 := NewUsersHandler()
+	v7 := NewHealthHandler()
+	v8 := make([]Handler, 0, 2)
+	v8 = append(v8, v6)
+	v8 = append(v8, v7)
+	v9 := NewServer(serverParams{Config: v1, Store: v4, Logger: v5, Handlers: v8})
+	v10 := NewServerRunnable(v9)
+	v11 := make([]godi.Runnable, 0, 1)
+	v11 = append(v11, v10)
+	return &WiringContainer{
+		Lifecycle:     v0,
+		Config:        v1,
+		Logger:        v5,
+		Server:        v9,
+		Runnables:     v11,
+		HandlersGroup: v8,
+		Store:         v4,
+	}, nil
+}
@@ -0,0 +1,39 @@
+// Package invalid holds wiring functions the generator must reject.
+package invalid
+
+import "github.com/assurrussa/godi"
+
+type (
+	A struct{}
+	B struct{}
+)
+
+func NewA(B) A { return A{} }
+
+func NewB(A) B { return B{} }
+
+func NewLazyA(godi.Lazy[B]) A { return A{} }
+
+func Cycle() godi.Dependencies {
+	return godi.CollectDependencies(godi.NewDependency(NewA), godi.NewDependency(NewB))
+}
+
+func Missing() godi.Dependencies {
+	return godi.NewSingleDependency(NewA)
+}
+
+func Lazy() godi.Dependencies {
+	return godi.CollectDependencies(godi.NewDependency(NewLazyA), godi.NewDependency(NewB))
+}
+
+func Closure() godi.Dependencies {
+	return godi.NewSingleDependency(func() A { return A{} })
+}
+
+func Duplicate() godi.Dependencies {
+	return godi.CollectDependencies(godi.NewDependency(NewLazyA), godi.NewDependency(NewLazyA))
+}
+
+func Dynamic(name string) godi.Dependencies {
+	return godi.NewSingleDependency(NewA, godi.WithName(name))
+}
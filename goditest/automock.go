@@ -0,0 +1,136 @@
+package goditest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/assurrussa/godi"
+)
+
+// MockFactory builds a value for a missing slot of one type; create it with Mock.
+type MockFactory struct {
+	typ     reflect.Type
+	factory func() any
+}
+
+// Mock registers factory for slots of type I that no provider satisfies.
+func Mock[I any](factory func() I) MockFactory {
+	return MockFactory{typ: reflect.TypeFor[I](), factory: func() any { return factory() }}
+}
+
+// MockedSlot is a slot AutoMock filled.
+type MockedSlot struct {
+	Type reflect.Type
+	Name string
+	// Consumers label the constructors that required the slot.
+	Consumers []string
+	// Factory reports whether a Mock factory built the value; otherwise it is the zero value
+	// (nil for interfaces, pointers, maps and funcs).
+	Factory bool
+}
+
+// AutoMockReport lists the slots AutoMock filled, in the order Container.Missing reports them.
+type AutoMockReport struct {
+	Slots []MockedSlot
+}
+
+// Empty reports whether no slot was filled.
+func (r *AutoMockReport) Empty() bool {
+	return len(r.Slots) == 0
+}
+
+func (r *AutoMockReport) String() string {
+	lines := make([]string, 0, len(r.Slots))
+	for _, s := range r.Slots {
+		label := s.Type.String()
+		if s.Name != "" {
+			label += fmt.Sprintf("[name=%s]", s.Name)
+		}
+		source := "zero value"
+		if s.Factory {
+			source = "mock factory"
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s, required by %s", label, source, strings.Join(s.Consumers, ", ")))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// AutoMock makes the next New call for t provide every missing required slot (see Container.Missing):
+// with the Mock factory for its type when one is given, otherwise with the zero value.
+// Filled slots are logged with t.Logf and listed in the returned report once New returns.
+//
+//	report := goditest.AutoMock(t, goditest.Mock[Mailer](func() Mailer { return &fakeMailer{} }))
+//	goditest.New(t, godi.WithDependencies(app.Dependencies()))
+func AutoMock(t testing.TB, factories ...MockFactory) *AutoMockReport {
+	t.Helper()
+
+	s := stateOf(t)
+	if s.container != nil {
+		t.Fatalf("goditest.AutoMock: call AutoMock before goditest.New")
+	}
+	s.autoMock = &autoMock{factories: factories, report: &AutoMockReport{}}
+	return s.autoMock.report
+}
+
+type autoMock struct {
+	factories []MockFactory
+	report    *AutoMockReport
+}
+
+// dependencies builds providers for the slots missing from a container built with opts.
+func (a *autoMock) dependencies(opts []godi.ContainerOption) (godi.Dependencies, error) {
+	probe, err := godi.NewContainer(opts...)
+	if err != nil {
+		return godi.Dependencies{}, err
+	}
+
+	deps := make([]godi.Dependency, 0)
+	index := map[string]int{}
+	for _, missing := range probe.Missing() {
+		key := missing.Type.String() + "|" + missing.Name
+		if i, ok := index[key]; ok {
+			a.report.Slots[i].Consumers = appendUnique(a.report.Slots[i].Consumers, missing.Consumer)
+			continue
+		}
+		index[key] = len(a.report.Slots)
+
+		slot := MockedSlot{Type: missing.Type, Name: missing.Name, Consumers: []string{missing.Consumer}}
+		value := func() reflect.Value { return reflect.Zero(missing.Type) }
+		for _, f := range a.factories {
+			if f.typ == missing.Type {
+				factory := f.factory
+				value = func() reflect.Value { return reflect.ValueOf(factory()) }
+				slot.Factory = true
+			}
+		}
+		a.report.Slots = append(a.report.Slots, slot)
+
+		fnType := reflect.FuncOf(nil, []reflect.Type{missing.Type}, false)
+		constructor := reflect.MakeFunc(fnType, func([]reflect.Value) []reflect.Value {
+			v := value()
+			if !v.IsValid() {
+				// A factory returned a nil interface.
+				v = reflect.Zero(missing.Type)
+			}
+			return []reflect.Value{v}
+		}).Interface()
+
+		opts := make([]godi.DependencyOption, 0, 1)
+		if missing.Name != "" {
+			opts = append(opts, godi.WithName(missing.Name))
+		}
+		deps = append(deps, godi.NewDependency(constructor, opts...))
+	}
+	return godi.CollectDependencies(deps...), nil
+}
+
+func appendUnique(list []string, item string) []string {
+	for _, existing := range list {
+		if existing == item {
+			return list
+		}
+	}
+	return append(list, item)
+}
@@ -0,0 +1,77 @@
+package goditest_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"go.uber.org/dig"
+
+	"github.com/assurrussa/godi"
+	"github.com/assurrussa/godi/goditest"
+)
+
+type fakeReader struct{}
+
+func (fakeReader) Read([]byte) (int, error) { return 0, io.EOF }
+
+type service struct {
+	reader io.Reader
+	writer io.Writer
+	name   string
+}
+
+type serviceParams struct {
+	dig.In
+
+	Reader io.Reader
+	Writer io.Writer `name:"out"`
+	Name   string
+}
+
+func newService(p serviceParams) *service {
+	return &service{reader: p.Reader, writer: p.Writer, name: p.Name}
+}
+
+func TestAutoMockFillsMissingSlots(t *testing.T) {
+	t.Parallel()
+
+	report := goditest.AutoMock(t, goditest.Mock[io.Reader](func() io.Reader { return fakeReader{} }))
+	goditest.New(t, godi.WithDependencies(godi.CollectDependencies(
+		godi.NewDependency(newService),
+		godi.NewDependency(func(io.Reader) int { return 0 }),
+	)))
+
+	var svc *service
+	goditest.Populate(t, &svc)
+	if _, ok := svc.reader.(fakeReader); !ok || svc.writer != nil || svc.name != "" {
+		t.Fatalf("unexpected service: %+v", svc)
+	}
+
+	if len(report.Slots) != 3 {
+		t.Fatalf("expected 3 filled slots, got:\n%s", report)
+	}
+	reader := report.Slots[0]
+	if reader.Type.String() != "io.Reader" || !reader.Factory || len(reader.Consumers) != 2 {
+		t.Fatalf("unexpected reader slot: %+v", reader)
+	}
+	out := report.String()
+	for _, want := range []string{"io.Reader: mock factory", "io.Writer[name=out]: zero value", "string: zero value"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected report to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestAutoMockLeavesProvidedSlots(t *testing.T) {
+	t.Parallel()
+
+	report := goditest.AutoMock(t)
+	goditest.New(t, godi.WithDependencies(godi.CollectDependencies(
+		godi.NewDependency(func() string { return "provided" }),
+		godi.NewDependency(func(string) *greeter { return &greeter{} }),
+	)))
+	if !report.Empty() {
+		t.Fatalf("expected empty report, got:\n%s", report)
+	}
+}
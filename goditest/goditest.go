@@ -0,0 +1,225 @@
+// Package goditest builds godi containers for tests.
+//
+// New builds and validates a container, starts its lifecycle and runnables and stops them when the test ends.
+// Replace and Populate work with the container New built for the same t:
+//
+//	goditest.Replace[Clock](t, fakeClock{})
+//	goditest.New(t, godi.WithDependencies(app.Dependencies()))
+//
+//	var svc *app.Service
+//	goditest.Populate(t, &svc)
+package goditest
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.uber.org/dig"
+
+	"github.com/assurrussa/godi"
+)
+
+// state is what goditest keeps per test: replacements for the next New call and the built container.
+type state struct {
+	replaces  []replacement
+	autoMock  *autoMock
+	container *godi.Container
+}
+
+type replacement struct {
+	typ reflect.Type
+	dep godi.Dependency
+}
+
+var (
+	mu     sync.Mutex
+	states = map[testing.TB]*state{}
+)
+
+func stateOf(t testing.TB) *state {
+	mu.Lock()
+	defer mu.Unlock()
+	if s, ok := states[t]; ok {
+		return s
+	}
+	s := &state{}
+	states[t] = s
+	t.Cleanup(func() {
+		mu.Lock()
+		defer mu.Unlock()
+		delete(states, t)
+	})
+	return s
+}
+
+// New builds a container from opts plus the replacements registered with Replace and the slots filled by
+// AutoMock, validates it, starts the container lifecycle (if a *godi.Lifecycle is provided) and every Runnable,
+// and registers t.Cleanup to stop them in reverse order. Wiring errors fail the test immediately.
+func New(t testing.TB, opts ...godi.ContainerOption) *godi.Container {
+	t.Helper()
+
+	s := stateOf(t)
+	if s.container != nil {
+		t.Fatalf("goditest.New: a container was already built for %s", t.Name())
+	}
+
+	checkReplacements(t, opts, s.replaces)
+	if len(s.replaces) > 0 {
+		deps := make([]godi.Dependency, 0, len(s.replaces))
+		for _, r := range s.replaces {
+			deps = append(deps, r.dep)
+		}
+		opts = append(opts, godi.WithDependencies(godi.CollectDependencies(deps...)))
+	}
+	if s.autoMock != nil {
+		mocks, err := s.autoMock.dependencies(opts)
+		if err != nil {
+			t.Fatalf("goditest.New: build container:\n%v", err)
+		}
+		opts = append(opts, godi.WithDependencies(mocks))
+		if !s.autoMock.report.Empty() {
+			t.Logf("goditest.AutoMock filled missing slots:\n%s", s.autoMock.report)
+		}
+	}
+
+	c, err := godi.NewContainer(opts...)
+	if err != nil {
+		t.Fatalf("goditest.New: build container:\n%v", err)
+	}
+	if err := c.Validate(); err != nil {
+		t.Fatalf("goditest.New: invalid wiring:\n%v", err)
+	}
+
+	lifecycle, err := startHooks(c)
+	if err != nil {
+		t.Fatalf("goditest.New: resolve lifecycle and runnables:\n%v", err)
+	}
+	if err := lifecycle.Start(t.Context()); err != nil {
+		t.Fatalf("goditest.New: start lifecycle:\n%v", err)
+	}
+	t.Cleanup(func() {
+		if err := lifecycle.Stop(context.Background()); err != nil {
+			t.Errorf("goditest: stop lifecycle: %v", err)
+		}
+	})
+
+	s.container = c
+	return c
+}
+
+type lifecycleParams struct {
+	dig.In
+	Lifecycle *godi.Lifecycle `optional:"true"`
+}
+
+// startHooks collects the container lifecycle and runnables into one lifecycle: the container
+// lifecycle starts first and stops last.
+func startHooks(c *godi.Container) (*godi.Lifecycle, error) {
+	var in lifecycleParams
+	if err := c.Invoke(func(p lifecycleParams) { in = p }); err != nil {
+		return nil, err
+	}
+	runnables, err := c.Runnables()
+	if err != nil {
+		return nil, err
+	}
+
+	lifecycle := godi.NewLifecycle()
+	if in.Lifecycle != nil {
+		lifecycle.Append(godi.Hook{OnStart: in.Lifecycle.Start, OnStop: in.Lifecycle.Stop})
+	}
+	for _, r := range runnables {
+		lifecycle.Append(godi.Hook(r))
+	}
+	return lifecycle, nil
+}
+
+// checkReplacements fails the test when a Replace call has no provider to override in the root scope,
+// which usually means the type is wrong or the provider is private to a module.
+func checkReplacements(t testing.TB, opts []godi.ContainerOption, replaces []replacement) {
+	t.Helper()
+
+	if len(replaces) == 0 {
+		return
+	}
+	probe, err := godi.NewContainer(opts...)
+	if err != nil {
+		t.Fatalf("goditest.New: build container:\n%v", err)
+	}
+	provided := map[string]bool{}
+	for _, p := range probe.Graph().Providers {
+		for _, token := range p.Provides {
+			if token.Name == "" && token.Group == "" {
+				provided[token.Type] = true
+			}
+		}
+	}
+	for _, r := range replaces {
+		if !provided[r.typ.String()] {
+			t.Fatalf("goditest.Replace[%s]: no root or exported module provider to replace", r.typ)
+		}
+	}
+}
+
+// Replace overrides the provider of T with a constant value in the container built by the next New call
+// for t. Providers private to a module are not affected.
+func Replace[T any](t testing.TB, value T) {
+	t.Helper()
+
+	s := stateOf(t)
+	if s.container != nil {
+		t.Fatalf("goditest.Replace[%s]: call Replace before goditest.New", reflect.TypeFor[T]())
+	}
+	s.replaces = append(s.replaces, replacement{
+		typ: reflect.TypeFor[T](),
+		dep: godi.Replace(func() T { return value }),
+	})
+}
+
+// Populate resolves the pointed-to types from the container built by New for t and stores them in targets:
+//
+//	var (
+//		db  *sql.DB
+//		svc *app.Service
+//	)
+//	goditest.Populate(t, &db, &svc)
+func Populate(t testing.TB, targets ...any) {
+	t.Helper()
+
+	s := stateOf(t)
+	if s.container == nil {
+		t.Fatalf("goditest.Populate: call goditest.New(t, ...) first")
+	}
+
+	values := make([]reflect.Value, 0, len(targets))
+	in := make([]reflect.Type, 0, len(targets))
+	for i, target := range targets {
+		v := reflect.ValueOf(target)
+		if v.Kind() != reflect.Pointer || v.IsNil() {
+			t.Fatalf("goditest.Populate: argument %d must be a non-nil pointer, got %T", i, target)
+		}
+		values = append(values, v.Elem())
+		in = append(in, v.Elem().Type())
+	}
+
+	fn := reflect.MakeFunc(reflect.FuncOf(in, nil, false), func(args []reflect.Value) []reflect.Value {
+		for i, arg := range args {
+			values[i].Set(arg)
+		}
+		return nil
+	})
+	if err := s.container.Invoke(fn.Interface()); err != nil {
+		t.Fatalf("goditest.Populate: %s:\n%v", typeList(in), err)
+	}
+}
+
+func typeList(types []reflect.Type) string {
+	labels := make([]string, 0, len(types))
+	for _, typ := range types {
+		labels = append(labels, typ.String())
+	}
+	return "(" + strings.Join(labels, ", ") + ")"
+}
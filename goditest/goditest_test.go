@@ -0,0 +1,199 @@
+package goditest_test
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/assurrussa/godi"
+	"github.com/assurrussa/godi/goditest"
+)
+
+type greeter struct {
+	name string
+}
+
+func newGreeter(name string) *greeter { return &greeter{name: name} }
+
+// fakeT records the first fatal message instead of failing the real test.
+type fakeT struct {
+	testing.TB
+
+	fatal    string
+	errors   []string
+	cleanups []func()
+}
+
+func (f *fakeT) Helper()      {}
+func (f *fakeT) Name() string { return "fake" }
+
+func (f *fakeT) Context() context.Context { return context.Background() }
+
+func (f *fakeT) Cleanup(fn func()) { f.cleanups = append(f.cleanups, fn) }
+
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeT) Fatalf(format string, args ...any) {
+	f.fatal = fmt.Sprintf(format, args...)
+	runtime.Goexit()
+}
+
+// runFake runs fn like a test body and then runs the registered cleanups.
+func runFake(t *testing.T, fn func(t testing.TB)) *fakeT {
+	t.Helper()
+
+	f := &fakeT{TB: t}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(f)
+	}()
+	<-done
+	for i := len(f.cleanups) - 1; i >= 0; i-- {
+		f.cleanups[i]()
+	}
+	return f
+}
+
+func TestNewStartsAndStopsLifecycle(t *testing.T) {
+	t.Parallel()
+
+	events := make([]string, 0)
+	record := func(event string) func(context.Context) error {
+		return func(context.Context) error {
+			events = append(events, event)
+			return nil
+		}
+	}
+
+	f := runFake(t, func(t testing.TB) {
+		goditest.New(t,
+			godi.WithDefaultLifecycle(),
+			godi.WithDependencies(godi.CollectDependencies(
+				godi.NewDependency(func(l *godi.Lifecycle) *greeter {
+					l.Append(godi.Hook{OnStart: record("start greeter"), OnStop: record("stop greeter")})
+					return &greeter{}
+				}),
+				godi.NewDependency(func(*greeter) godi.Runnable {
+					return godi.Runnable{OnStart: record("start runnable"), OnStop: record("stop runnable")}
+				}),
+			)),
+		)
+		if got := strings.Join(events, ", "); got != "start greeter, start runnable" {
+			t.Fatalf("unexpected events after New: %s", got)
+		}
+	})
+	if f.fatal != "" || len(f.errors) > 0 {
+		t.Fatalf("unexpected failures: %q %v", f.fatal, f.errors)
+	}
+
+	want := "start greeter, start runnable, stop runnable, stop greeter"
+	if got := strings.Join(events, ", "); got != want {
+		t.Fatalf("unexpected events after cleanup: %s", got)
+	}
+}
+
+func TestReplaceAndPopulate(t *testing.T) {
+	t.Parallel()
+
+	goditest.Replace[string](t, "test")
+	goditest.New(t, godi.WithDependencies(godi.CollectDependencies(
+		godi.NewDependency(func() string { return "prod" }),
+		godi.NewDependency(newGreeter),
+		godi.NewDependency(func() int { return 42 }),
+	)))
+
+	var (
+		g *greeter
+		n int
+	)
+	goditest.Populate(t, &g, &n)
+	if g.name != "test" || n != 42 {
+		t.Fatalf("unexpected values: %q, %d", g.name, n)
+	}
+}
+
+func TestReplaceModuleProvider(t *testing.T) {
+	t.Parallel()
+
+	goditest.Replace[string](t, "test")
+	goditest.New(t, godi.WithModules(godi.NewModule("greeting", godi.CollectDependencies(
+		godi.NewDependency(func() string { return "prod" }),
+		godi.NewDependency(newGreeter),
+	))))
+
+	var g *greeter
+	goditest.Populate(t, &g)
+	if g.name != "test" {
+		t.Fatalf("expected replaced name, got %q", g.name)
+	}
+}
+
+func TestNewFailsOnInvalidWiring(t *testing.T) {
+	t.Parallel()
+
+	f := runFake(t, func(t testing.TB) {
+		goditest.New(t, godi.WithDependencies(godi.NewSingleDependency(newGreeter)))
+	})
+	if !strings.Contains(f.fatal, "goditest.New: invalid wiring") || !strings.Contains(f.fatal, "string") {
+		t.Fatalf("unexpected failure message: %q", f.fatal)
+	}
+}
+
+func TestNewFailsWhenStartFails(t *testing.T) {
+	t.Parallel()
+
+	f := runFake(t, func(t testing.TB) {
+		goditest.New(t, godi.WithDependencies(godi.NewSingleDependency(func() godi.Runnable {
+			return godi.Runnable{OnStart: func(context.Context) error { return fmt.Errorf("port in use") }}
+		})))
+	})
+	if !strings.Contains(f.fatal, "start lifecycle") || !strings.Contains(f.fatal, "port in use") {
+		t.Fatalf("unexpected failure message: %q", f.fatal)
+	}
+}
+
+func TestReplaceWithoutProviderFails(t *testing.T) {
+	t.Parallel()
+
+	f := runFake(t, func(t testing.TB) {
+		goditest.Replace[int](t, 1)
+		goditest.New(t, godi.WithDependencies(godi.NewSingleDependency(func() string { return "" })))
+	})
+	if !strings.Contains(f.fatal, "goditest.Replace[int]: no root or exported module provider") {
+		t.Fatalf("unexpected failure message: %q", f.fatal)
+	}
+}
+
+func TestPopulateFailures(t *testing.T) {
+	t.Parallel()
+
+	f := runFake(t, func(t testing.TB) {
+		var g *greeter
+		goditest.Populate(t, &g)
+	})
+	if !strings.Contains(f.fatal, "call goditest.New(t, ...) first") {
+		t.Fatalf("unexpected failure message: %q", f.fatal)
+	}
+
+	f = runFake(t, func(t testing.TB) {
+		goditest.New(t, godi.WithDependencies(godi.NewSingleDependency(func() string { return "" })))
+		var g *greeter
+		goditest.Populate(t, &g)
+	})
+	if !strings.Contains(f.fatal, "goditest.Populate: (*goditest_test.greeter)") {
+		t.Fatalf("unexpected failure message: %q", f.fatal)
+	}
+
+	f = runFake(t, func(t testing.TB) {
+		goditest.New(t)
+		goditest.Populate(t, "value")
+	})
+	if !strings.Contains(f.fatal, "argument 0 must be a non-nil pointer") {
+		t.Fatalf("unexpected failure message: %q", f.fatal)
+	}
+}
@@ -0,0 +1,237 @@
+package goditest
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/assurrussa/godi"
+)
+
+// UpdateGoldenEnv names the environment variable that makes AssertGraphGolden (re)write golden files when it
+// is set to a true value such as "1". An environment variable is used by default so test binaries keep their
+// own flags; RegisterUpdateFlag adds -update for packages that want it.
+const UpdateGoldenEnv = "GODI_UPDATE_GOLDEN"
+
+var (
+	registerUpdateFlag sync.Once
+	updateFlag         *bool
+)
+
+// RegisterUpdateFlag opts a test binary into the conventional -update flag, which rewrites golden files like
+// GODI_UPDATE_GOLDEN=1. Call it from an init function of the test package; repeated calls register the flag once:
+//
+//	func init() { goditest.RegisterUpdateFlag() }
+//
+// It panics like flag.Bool if the binary already defines a different -update flag.
+func RegisterUpdateFlag() {
+	registerUpdateFlag.Do(func() {
+		updateFlag = flag.Bool("update", false, "rewrite godi golden files")
+	})
+}
+
+func updateGolden() bool {
+	if updateFlag != nil && *updateFlag {
+		return true
+	}
+	update, _ := strconv.ParseBool(os.Getenv(UpdateGoldenEnv))
+	return update
+}
+
+// AssertGraphGolden compares the graphs of every scope (Container.GraphModules) with a golden file
+// and fails the test with the differing lines. Run the test with GODI_UPDATE_GOLDEN=1, or with -update after
+// RegisterUpdateFlag, to (re)write the file.
+//
+// The snapshot is deterministic: scopes, providers, tokens and edges are sorted, providers are identified
+// by key, constructor or type rather than registration index, and source locations keep only the file name.
+func AssertGraphGolden(t testing.TB, c *godi.Container, path string) {
+	t.Helper()
+
+	got := GraphSnapshot(c)
+	if updateGolden() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatalf("goditest.AssertGraphGolden: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o600); err != nil {
+			t.Fatalf("goditest.AssertGraphGolden: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("goditest.AssertGraphGolden: %s does not exist; run the test with "+UpdateGoldenEnv+"=1 to create it", path)
+	}
+	if err != nil {
+		t.Fatalf("goditest.AssertGraphGolden: %v", err)
+	}
+	if string(want) != got {
+		t.Fatalf("goditest.AssertGraphGolden: wiring differs from %s (run the test with "+UpdateGoldenEnv+"=1 to accept):\n%s",
+			path, lineDiff(string(want), got))
+	}
+}
+
+// GraphSnapshot renders the graphs of every scope in the format used by AssertGraphGolden.
+func GraphSnapshot(c *godi.Container) string {
+	graphs := c.GraphModules()
+	scopes := make([]string, 0, len(graphs))
+	for scope := range graphs {
+		scopes = append(scopes, scope)
+	}
+	sort.Slice(scopes, func(i, j int) bool {
+		// The root scope goes first, modules follow by name.
+		if (scopes[i] == "root") != (scopes[j] == "root") {
+			return scopes[i] == "root"
+		}
+		return scopes[i] < scopes[j]
+	})
+
+	var b strings.Builder
+	for i, scope := range scopes {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		writeScope(&b, scope, graphs[scope])
+	}
+	return b.String()
+}
+
+func writeScope(b *strings.Builder, scope string, graph godi.Graph) {
+	labels := make(map[string]string, len(graph.Providers))
+	providers := append([]godi.ProviderNode(nil), graph.Providers...)
+	for _, p := range providers {
+		labels[p.ID] = providerLabel(p)
+	}
+	sort.SliceStable(providers, func(i, j int) bool {
+		a, b := providers[i], providers[j]
+		if a.Module != b.Module {
+			return a.Module < b.Module
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.ID < b.ID
+	})
+
+	_, _ = fmt.Fprintf(b, "scope %s\n", scope)
+	for _, p := range providers {
+		_, _ = fmt.Fprintf(b, "  %s %s\n", p.Kind, labels[p.ID])
+		if p.File != "" {
+			_, _ = fmt.Fprintf(b, "    file: %s\n", filepath.Base(p.File))
+		}
+		if p.Private {
+			b.WriteString("    private\n")
+		}
+		writeTokens(b, "provides", p.Provides)
+		writeTokens(b, "requires", p.Requires)
+	}
+
+	edges := make([]string, 0, len(graph.Edges))
+	for _, e := range graph.Edges {
+		to := labels[e.To]
+		if e.Missing {
+			to = "(missing)"
+		}
+		token := tokenLabel(godi.GraphToken{Type: e.Type, Name: e.Name, Group: e.Group, Optional: e.Optional, Lazy: e.Lazy})
+		edges = append(edges, fmt.Sprintf("%s -> %s [%s]", labels[e.From], to, token))
+	}
+	sort.Strings(edges)
+	if len(edges) > 0 {
+		b.WriteString("  edges\n")
+	}
+	for _, edge := range edges {
+		_, _ = fmt.Fprintf(b, "    %s\n", edge)
+	}
+}
+
+func providerLabel(p godi.ProviderNode) string {
+	label := p.Key
+	if label == "" {
+		label = p.Constructor
+	}
+	if label == "" {
+		label = p.Type
+	}
+	if p.Type != "" && label != p.Type {
+		label += " " + p.Type
+	}
+	if p.Name != "" {
+		label += " name:" + p.Name
+	}
+	if p.Group != "" {
+		label += " group:" + p.Group
+	}
+	if p.Module != "" {
+		label = p.Module + "/" + label
+	}
+	return label
+}
+
+func writeTokens(b *strings.Builder, title string, tokens []godi.GraphToken) {
+	if len(tokens) == 0 {
+		return
+	}
+	labels := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		labels = append(labels, tokenLabel(token))
+	}
+	sort.Strings(labels)
+	_, _ = fmt.Fprintf(b, "    %s: %s\n", title, strings.Join(labels, ", "))
+}
+
+func tokenLabel(token godi.GraphToken) string {
+	parts := []string{token.Type}
+	if token.Name != "" {
+		parts = append(parts, "name:"+token.Name)
+	}
+	if token.Group != "" {
+		parts = append(parts, "group:"+token.Group)
+	}
+	if token.Optional {
+		parts = append(parts, "optional")
+	}
+	if token.Lazy {
+		parts = append(parts, "lazy")
+	}
+	return strings.Join(parts, " ")
+}
+
+// lineDiff lists lines only present in want (-) or got (+), in file order.
+func lineDiff(want, got string) string {
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+	counts := map[string]int{}
+	for _, line := range gotLines {
+		counts[line]++
+	}
+	removed := make([]string, 0)
+	for _, line := range wantLines {
+		if counts[line] > 0 {
+			counts[line]--
+			continue
+		}
+		removed = append(removed, "- "+line)
+	}
+
+	counts = map[string]int{}
+	for _, line := range wantLines {
+		counts[line]++
+	}
+	added := make([]string, 0)
+	for _, line := range gotLines {
+		if counts[line] > 0 {
+			counts[line]--
+			continue
+		}
+		added = append(added, "+ "+line)
+	}
+	return strings.Join(append(removed, added...), "\n")
+}
@@ -0,0 +1,110 @@
+package goditest_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/assurrussa/godi"
+	"github.com/assurrussa/godi/goditest"
+)
+
+func newServer(g *greeter) *server { return &server{greeter: g} }
+
+func newName() string { return "golden" }
+
+func goldenOptions() []godi.ContainerOption {
+	return []godi.ContainerOption{
+		godi.WithModules(godi.NewModule("web", godi.CollectDependencies(
+			godi.NewDependency(newName, godi.Private()),
+			godi.NewDependency(newGreeter),
+		))),
+		godi.WithDependencies(godi.NewSingleDependency(newServer, godi.WithKey("server"))),
+	}
+}
+
+func TestAssertGraphGolden(t *testing.T) {
+	t.Parallel()
+
+	c := goditest.New(t, goldenOptions()...)
+	goditest.AssertGraphGolden(t, c, "testdata/wiring.golden")
+
+	snapshot := goditest.GraphSnapshot(c)
+	if strings.Contains(snapshot, string(filepath.Separator)+"goditest"+string(filepath.Separator)) {
+		t.Fatalf("expected file paths to be stripped:\n%s", snapshot)
+	}
+}
+
+func TestAssertGraphGoldenReportsDifferences(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "wiring.golden")
+	golden, err := os.ReadFile("testdata/wiring.golden")
+	if err != nil {
+		t.Fatalf("read golden: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(strings.ReplaceAll(string(golden), "newName", "oldName")), 0o600); err != nil {
+		t.Fatalf("write golden: %v", err)
+	}
+
+	f := runFake(t, func(t testing.TB) {
+		goditest.AssertGraphGolden(t, goditest.New(t, goldenOptions()...), path)
+	})
+	if !strings.Contains(f.fatal, "wiring differs") ||
+		!strings.Contains(f.fatal, "- ") || !strings.Contains(f.fatal, "oldName") ||
+		!strings.Contains(f.fatal, "+ ") {
+		t.Fatalf("unexpected failure message: %q", f.fatal)
+	}
+
+	f = runFake(t, func(t testing.TB) {
+		goditest.AssertGraphGolden(t, goditest.New(t, goldenOptions()...), filepath.Join(t.TempDir(), "missing.golden"))
+	})
+	if !strings.Contains(f.fatal, "does not exist; run the test with GODI_UPDATE_GOLDEN=1") {
+		t.Fatalf("unexpected failure message: %q", f.fatal)
+	}
+}
+
+// TestAssertGraphGoldenUpdate sets the process environment, so it must not run in parallel.
+func TestAssertGraphGoldenUpdate(t *testing.T) {
+	t.Setenv(goditest.UpdateGoldenEnv, "1")
+
+	path := filepath.Join(t.TempDir(), "nested", "wiring.golden")
+	c := goditest.New(t, goldenOptions()...)
+	goditest.AssertGraphGolden(t, c, path)
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read updated golden: %v", err)
+	}
+	if string(got) != goditest.GraphSnapshot(c) {
+		t.Fatalf("unexpected golden content:\n%s", got)
+	}
+}
+
+// TestAssertGraphGoldenUpdateFlag sets the process flags, so it must not run in parallel.
+func TestAssertGraphGoldenUpdateFlag(t *testing.T) {
+	goditest.RegisterUpdateFlag()
+	goditest.RegisterUpdateFlag()
+	if err := flag.Set("update", "true"); err != nil {
+		t.Fatalf("set -update: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := flag.Set("update", "false"); err != nil {
+			t.Errorf("reset -update: %v", err)
+		}
+	})
+
+	path := filepath.Join(t.TempDir(), "wiring.golden")
+	c := goditest.New(t, goldenOptions()...)
+	goditest.AssertGraphGolden(t, c, path)
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read updated golden: %v", err)
+	}
+	if string(got) != goditest.GraphSnapshot(c) {
+		t.Fatalf("unexpected golden content:\n%s", got)
+	}
+}
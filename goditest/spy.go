@@ -0,0 +1,145 @@
+package goditest
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/assurrussa/godi"
+)
+
+// SpyRecorder records every resolution of a slot observed through a root-scope decorator.
+type SpyRecorder[T any] struct {
+	mu    sync.Mutex
+	calls []SpyCall[T]
+}
+
+// SpyCall is one recorded resolution: the produced value and the arguments its constructor was called with.
+type SpyCall[T any] struct {
+	Value T
+	// Inputs holds the constructor arguments in parameter order; a dig.In parameter is recorded as its struct.
+	// It is only recorded by a spy installed with Wrap.
+	Inputs []any
+}
+
+// Spy returns a recorder for the unnamed slot of T; install it with Option, or with Wrap to also record
+// the constructor arguments:
+//
+//	spy := goditest.Spy[*sql.DB]()
+//	goditest.New(t, godi.WithDependencies(app.Dependencies()), spy.Wrap(app.NewDB))
+//	if spy.Calls() != 1 { ... }
+//	cfg := spy.Records()[0].Inputs[0].(*app.Config)
+//
+// The decorator installed by Option runs once per scope that resolves T, after the constructor, so Calls
+// reports whether (and in how many scopes) the value was built and Values holds the produced instances.
+// A slot can only have one root decorator, so Option cannot be combined with a Decorate of T in the root scope.
+func Spy[T any]() *SpyRecorder[T] {
+	return &SpyRecorder[T]{}
+}
+
+// Option installs the spy decorator.
+func (s *SpyRecorder[T]) Option() godi.ContainerOption {
+	return godi.WithDependencies(godi.CollectDependencies(godi.Decorate(func(v T) T {
+		s.record(v, nil)
+		return v
+	})))
+}
+
+// Wrap installs the spy as a root godi.Replace of T with constructor, called through a wrapper that records
+// the produced value together with the arguments, so Records carries the Inputs of each call. Pass the
+// constructor the wiring provides T with to keep its behaviour; failed calls are not recorded.
+// Wrap panics if constructor is a function without a T result.
+func (s *SpyRecorder[T]) Wrap(constructor any) godi.ContainerOption {
+	fn := reflect.ValueOf(constructor)
+	if fn.Kind() != reflect.Func {
+		// Let the container report the invalid constructor like any other.
+		return godi.WithDependencies(godi.CollectDependencies(godi.Replace(constructor)))
+	}
+	fnType := fn.Type()
+	result := -1
+	for i := range fnType.NumOut() {
+		if fnType.Out(i) == reflect.TypeFor[T]() {
+			result = i
+			break
+		}
+	}
+	if result < 0 {
+		panic(fmt.Sprintf("goditest: Wrap needs a constructor of %s, got %s", reflect.TypeFor[T](), fnType))
+	}
+
+	wrapped := reflect.MakeFunc(fnType, func(args []reflect.Value) []reflect.Value {
+		var results []reflect.Value
+		if fnType.IsVariadic() {
+			results = fn.CallSlice(args)
+		} else {
+			results = fn.Call(args)
+		}
+		last := results[len(results)-1]
+		if last.Type() == reflect.TypeFor[error]() && !last.IsNil() {
+			return results
+		}
+		inputs := make([]any, 0, len(args))
+		for _, arg := range args {
+			inputs = append(inputs, arg.Interface())
+		}
+		value, _ := results[result].Interface().(T)
+		s.record(value, inputs)
+		return results
+	})
+	return godi.WithDependencies(godi.CollectDependencies(godi.Replace(wrapped.Interface())))
+}
+
+func (s *SpyRecorder[T]) record(value T, inputs []any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = append(s.calls, SpyCall[T]{Value: value, Inputs: inputs})
+}
+
+// Calls returns how many times the slot was resolved.
+func (s *SpyRecorder[T]) Calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.calls)
+}
+
+// Records returns the recorded resolutions with their constructor arguments in resolution order.
+func (s *SpyRecorder[T]) Records() []SpyCall[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]SpyCall[T](nil), s.calls...)
+}
+
+// Values returns the resolved instances in resolution order.
+func (s *SpyRecorder[T]) Values() []T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	values := make([]T, 0, len(s.calls))
+	for _, call := range s.calls {
+		values = append(values, call.Value)
+	}
+	return values
+}
+
+// Last returns the most recent resolved instance and false if the slot was never resolved.
+func (s *SpyRecorder[T]) Last() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.calls) == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.calls[len(s.calls)-1].Value, true
+}
+
+// Capture stores the resolved value of the unnamed slot of T in target when it is built:
+//
+//	var db *sql.DB
+//	goditest.New(t, godi.WithDependencies(app.Dependencies()), goditest.Capture(&db))
+//
+// Like Spy, it installs a root-scope decorator of T.
+func Capture[T any](target *T) godi.ContainerOption {
+	return godi.WithDependencies(godi.CollectDependencies(godi.Decorate(func(v T) T {
+		*target = v
+		return v
+	})))
+}
@@ -0,0 +1,158 @@
+package goditest_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"go.uber.org/dig"
+
+	"github.com/assurrussa/godi"
+	"github.com/assurrussa/godi/goditest"
+)
+
+type server struct {
+	greeter *greeter
+}
+
+func TestSpyRecordsResolutions(t *testing.T) {
+	t.Parallel()
+
+	spy := goditest.Spy[*greeter]()
+	unused := goditest.Spy[int]()
+	goditest.New(t,
+		godi.WithDependencies(godi.CollectDependencies(
+			godi.NewDependency(func() string { return "spy" }),
+			godi.NewDependency(newGreeter),
+			godi.NewDependency(func(g *greeter) *server { return &server{greeter: g} }),
+			godi.NewDependency(func() int { return 1 }),
+		)),
+		spy.Option(),
+		unused.Option(),
+	)
+
+	if spy.Calls() != 0 {
+		t.Fatalf("expected lazy construction, got %d calls", spy.Calls())
+	}
+
+	var s *server
+	goditest.Populate(t, &s)
+	goditest.Populate(t, &s)
+
+	last, ok := spy.Last()
+	if spy.Calls() != 1 || !ok || last != s.greeter || last.name != "spy" {
+		t.Fatalf("unexpected spy state: calls=%d last=%v", spy.Calls(), last)
+	}
+	if values := spy.Values(); len(values) != 1 || values[0] != s.greeter {
+		t.Fatalf("unexpected values: %v", values)
+	}
+	records := spy.Records()
+	if len(records) != 1 || records[0].Value != s.greeter {
+		t.Fatalf("unexpected records: %+v", records)
+	}
+	if records[0].Inputs != nil {
+		t.Fatalf("expected no inputs from the decorator spy, got %v", records[0].Inputs)
+	}
+	if _, ok := unused.Last(); ok || unused.Calls() != 0 {
+		t.Fatalf("expected unresolved spy, got %d calls", unused.Calls())
+	}
+}
+
+func TestCaptureStoresInstance(t *testing.T) {
+	t.Parallel()
+
+	var captured *greeter
+	goditest.New(t,
+		godi.WithModules(godi.NewModule("web", godi.CollectDependencies(
+			godi.NewDependency(func() string { return "capture" }, godi.Private()),
+			godi.NewDependency(newGreeter),
+		))),
+		goditest.Capture(&captured),
+	)
+
+	var g *greeter
+	goditest.Populate(t, &g)
+	if captured != g || captured.name != "capture" {
+		t.Fatalf("expected captured instance, got %v", captured)
+	}
+}
+
+func TestSpyConflictsWithRootDecorator(t *testing.T) {
+	t.Parallel()
+
+	f := runFake(t, func(t testing.TB) {
+		goditest.New(t,
+			godi.WithDependencies(godi.CollectDependencies(
+				godi.NewDependency(func() string { return "" }),
+				godi.Decorate(func(s string) string { return s + "!" }),
+			)),
+			goditest.Spy[string]().Option(),
+		)
+	})
+	if !strings.Contains(f.fatal, "goditest.New") {
+		t.Fatalf("expected wiring failure, got %q", f.fatal)
+	}
+}
+
+func TestSpyRecordsParameterObjectInputs(t *testing.T) {
+	t.Parallel()
+
+	type serverIn struct {
+		dig.In
+		Greeter *greeter `name:"main"`
+	}
+	newServer := func(in serverIn) *server { return &server{greeter: in.Greeter} }
+	spy := goditest.Spy[*server]()
+	goditest.New(t,
+		godi.WithDependencies(godi.CollectDependencies(
+			godi.NewDependency(func() *greeter { return &greeter{name: "main"} }, godi.WithName("main")),
+			godi.NewDependency(newServer),
+		)),
+		spy.Wrap(newServer),
+	)
+
+	var s *server
+	goditest.Populate(t, &s)
+	records := spy.Records()
+	if len(records) != 1 || len(records[0].Inputs) != 1 {
+		t.Fatalf("unexpected records: %+v", records)
+	}
+	in, ok := records[0].Inputs[0].(serverIn)
+	if !ok || in.Greeter != s.greeter {
+		t.Fatalf("expected the parameter object with the named greeter, got %#v", records[0].Inputs[0])
+	}
+}
+
+func TestSpyWrapRecordsInputs(t *testing.T) {
+	t.Parallel()
+
+	spy := goditest.Spy[*greeter]()
+	goditest.New(t,
+		godi.WithDependencies(godi.CollectDependencies(
+			godi.NewDependency(func() string { return "wrap" }),
+			godi.NewDependency(newGreeter),
+		)),
+		spy.Wrap(newGreeter),
+	)
+
+	var g *greeter
+	goditest.Populate(t, &g)
+	records := spy.Records()
+	if len(records) != 1 || records[0].Value != g || g.name != "wrap" {
+		t.Fatalf("unexpected records: %+v", records)
+	}
+	if inputs := records[0].Inputs; len(inputs) != 1 || inputs[0] != "wrap" {
+		t.Fatalf("expected the constructor argument \"wrap\", got %v", inputs)
+	}
+}
+
+func TestSpyWrapRejectsOtherConstructors(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), "needs a constructor of *goditest_test.greeter") {
+			t.Fatalf("expected a panic about the constructor, got %v", r)
+		}
+	}()
+	goditest.Spy[*greeter]().Wrap(func() string { return "" })
+}
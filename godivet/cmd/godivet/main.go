@@ -0,0 +1,15 @@
+// Command godivet reports godi registrations that the container rejects at runtime.
+//
+//	godivet ./...
+//	go vet -vettool=$(which godivet) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/assurrussa/godi/godivet"
+)
+
+func main() {
+	singlechecker.Main(godivet.Analyzer)
+}
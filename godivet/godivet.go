@@ -0,0 +1,196 @@
+// Package godivet defines an analyzer that reports godi registrations the container rejects at runtime.
+//
+// It checks calls to godi.NewDependency, NewSingleDependency, Replace, Decorate, WithMatch and NewMatching
+// whose constructor and options are written inline:
+//
+//   - constructors that do not return T or (T, error), such as (error, T);
+//   - WithMatch and NewMatching arguments that are not pointers to interfaces, or interfaces the constructor
+//     result does not implement;
+//   - WithName combined with WithGroup or a Runnable constructor, and Runnable constructors with WithGroup;
+//   - Replace of group dependencies;
+//   - Decorate with WithName, WithGroup or WithMatch.
+//
+// Options passed through variables or spread slices are not followed.
+package godivet
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const godiPath = "github.com/assurrussa/godi"
+
+// Analyzer reports godi misuse; run it with cmd/godivet, go vet -vettool or golangci-lint.
+var Analyzer = &analysis.Analyzer{
+	Name:     "godivet",
+	Doc:      "report godi registrations that the container rejects at runtime",
+	URL:      "https://pkg.go.dev/github.com/assurrussa/godi/godivet",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	if !importsGodi(pass.Pkg) {
+		return nil, nil //nolint:nilnil // the analyzer has no result
+	}
+
+	insp, _ := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	insp.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call, _ := n.(*ast.CallExpr)
+		fn := godiFunc(pass.TypesInfo, call)
+		if fn == nil {
+			return
+		}
+		switch fn.Name() {
+		case "NewDependency", "NewSingleDependency":
+			checkDependency(pass, fn.Pkg(), call, "")
+		case "Replace", "Decorate":
+			checkDependency(pass, fn.Pkg(), call, fn.Name())
+		case "WithMatch":
+			if len(call.Args) == 1 {
+				checkInterfacePointer(pass, call.Args[0], "godi.WithMatch")
+			}
+		case "NewMatching":
+			if call.Ellipsis.IsValid() {
+				return
+			}
+			for _, arg := range call.Args[min(1, len(call.Args)):] {
+				checkInterfacePointer(pass, arg, "godi.NewMatching")
+			}
+		}
+	})
+	return nil, nil //nolint:nilnil // the analyzer has no result
+}
+
+// checkDependency checks one registration; kind is "" for a provide, "Replace" or "Decorate".
+func checkDependency(pass *analysis.Pass, godi *types.Package, call *ast.CallExpr, kind string) {
+	if len(call.Args) == 0 {
+		return
+	}
+	sig, ok := pass.TypesInfo.TypeOf(call.Args[0]).(*types.Signature)
+	if !ok {
+		return
+	}
+	if !validResults(sig.Results()) {
+		pass.Reportf(call.Args[0].Pos(), "constructor must return value or (value, error), returns %s", sig.Results())
+		return
+	}
+	result := sig.Results().At(0).Type()
+
+	options := map[string]ast.Expr{}
+	matches := make([]ast.Expr, 0)
+	optionArgs := call.Args[1:]
+	if call.Ellipsis.IsValid() {
+		optionArgs = optionArgs[:len(optionArgs)-1]
+	}
+	for _, arg := range optionArgs {
+		option, ok := ast.Unparen(arg).(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+		if fn := godiFunc(pass.TypesInfo, option); fn != nil {
+			options[fn.Name()] = option
+			if fn.Name() == "WithMatch" && len(option.Args) == 1 {
+				matches = append(matches, option.Args[0])
+			}
+		}
+	}
+
+	if kind == "Decorate" {
+		for _, name := range []string{"WithName", "WithGroup", "WithMatch"} {
+			if option, ok := options[name]; ok {
+				pass.Reportf(option.Pos(), "godi.Decorate does not support %s; use dig.Out in the decorator result", name)
+			}
+		}
+		return
+	}
+
+	runnable := types.Identical(result, godi.Scope().Lookup("Runnable").Type())
+	withName, hasName := options["WithName"]
+	withGroup, hasGroup := options["WithGroup"]
+	switch {
+	case runnable && hasGroup:
+		pass.Reportf(withGroup.Pos(), "Runnable cannot be used with WithGroup")
+	case hasName && hasGroup:
+		pass.Reportf(withName.Pos(), "WithName cannot be used with WithGroup")
+	case hasName && runnable:
+		pass.Reportf(withName.Pos(), "WithName cannot be used with Runnable")
+	case kind == "Replace" && (hasGroup || runnable):
+		pass.Reportf(call.Pos(), "godi.Replace is not supported for group dependencies")
+	}
+
+	for _, match := range matches {
+		ptr, ok := pass.TypesInfo.TypeOf(match).(*types.Pointer)
+		if !ok {
+			continue
+		}
+		iface, ok := ptr.Elem().Underlying().(*types.Interface)
+		if ok && !types.Implements(result, iface) {
+			qualifier := types.RelativeTo(pass.Pkg)
+			pass.Reportf(match.Pos(), "constructor result %s does not implement %s",
+				types.TypeString(result, qualifier), types.TypeString(ptr.Elem(), qualifier))
+		}
+	}
+}
+
+func checkInterfacePointer(pass *analysis.Pass, expr ast.Expr, fn string) {
+	t := pass.TypesInfo.TypeOf(expr)
+	if t == nil || types.IsInterface(t) {
+		// The dynamic type is not known statically.
+		return
+	}
+	if ptr, ok := t.(*types.Pointer); !ok || !types.IsInterface(ptr.Elem()) {
+		pass.Reportf(expr.Pos(), "%s expects a pointer to an interface such as (*io.Reader)(nil), got %s",
+			fn, types.TypeString(t, types.RelativeTo(pass.Pkg)))
+	}
+}
+
+// validResults mirrors validateConstructor: func(...) T or func(...) (T, error).
+func validResults(results *types.Tuple) bool {
+	errType := types.Universe.Lookup("error").Type()
+	isErr := func(i int) bool { return types.AssignableTo(results.At(i).Type(), errType) }
+	switch results.Len() {
+	case 1:
+		return !isErr(0)
+	case 2:
+		return !isErr(0) && isErr(1)
+	default:
+		return false
+	}
+}
+
+func godiFunc(info *types.Info, call *ast.CallExpr) *types.Func {
+	var ident *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		ident = fun
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	default:
+		return nil
+	}
+	fn, ok := info.Uses[ident].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != godiPath {
+		return nil
+	}
+	if sig, _ := fn.Type().(*types.Signature); sig.Recv() != nil {
+		return nil
+	}
+	return fn
+}
+
+func importsGodi(pkg *types.Package) bool {
+	if pkg.Path() == godiPath {
+		return true
+	}
+	for _, imp := range pkg.Imports() {
+		if imp.Path() == godiPath {
+			return true
+		}
+	}
+	return false
+}
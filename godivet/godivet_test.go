@@ -0,0 +1,15 @@
+package godivet_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/assurrussa/godi/godivet"
+)
+
+func TestAnalyzer(t *testing.T) {
+	t.Parallel()
+
+	analysistest.Run(t, analysistest.TestData(), godivet.Analyzer, "./a")
+}
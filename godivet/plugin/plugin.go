@@ -0,0 +1,31 @@
+// Package plugin registers godivet as a golangci-lint module plugin.
+//
+// Reference it from .custom-gcl.yml and enable the "godivet" linter with type "module" in .golangci.yml;
+// see docs/en/vet.md.
+package plugin
+
+import (
+	"github.com/golangci/plugin-module-register/register"
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/assurrussa/godi/godivet"
+)
+
+func init() { //nolint:gochecknoinits // golangci-lint discovers module plugins through init registration
+	register.Plugin("godivet", New)
+}
+
+// New returns the godivet linter; it has no settings.
+func New(any) (register.LinterPlugin, error) {
+	return linter{}, nil
+}
+
+type linter struct{}
+
+func (linter) BuildAnalyzers() ([]*analysis.Analyzer, error) {
+	return []*analysis.Analyzer{godivet.Analyzer}, nil
+}
+
+func (linter) GetLoadMode() string {
+	return register.LoadModeTypesInfo
+}
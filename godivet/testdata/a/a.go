@@ -0,0 +1,60 @@
+package a
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/assurrussa/godi"
+)
+
+type reader struct{}
+
+func (reader) Read([]byte) (int, error) { return 0, nil }
+
+type Config struct{}
+
+func newReader() reader { return reader{} }
+
+func newConfig() Config { return Config{} }
+
+func newErrFirst() (error, Config) { return nil, Config{} }
+
+func newOnlyError() error { return errors.New("boom") }
+
+func newRunnable() godi.Runnable {
+	return godi.Runnable{OnStart: func(context.Context) error { return nil }}
+}
+
+func valid() godi.Dependencies {
+	return godi.CollectDependencies(
+		godi.NewDependency(newReader, godi.WithMatch((*io.Reader)(nil))),
+		godi.NewDependency(newConfig, godi.WithName("primary")),
+		godi.NewDependency(newConfig, godi.WithGroup("configs")),
+		godi.NewDependency(newRunnable),
+		godi.Replace(newConfig),
+		godi.Decorate(func(c Config) Config { return c }),
+	)
+}
+
+func invalid(iface any) godi.Dependencies {
+	return godi.CollectDependencies(
+		godi.NewDependency(newErrFirst),                                  // want `constructor must return value or \(value, error\), returns \(error, example.com/vet/a.Config\)`
+		godi.NewDependency(newOnlyError),                                 // want `constructor must return value or \(value, error\)`
+		godi.NewDependency(newConfig, godi.WithMatch(&Config{})),         // want `godi.WithMatch expects a pointer to an interface`
+		godi.NewDependency(newConfig, godi.WithMatch((*io.Reader)(nil))), // want `constructor result Config does not implement io.Reader`
+		godi.NewDependency(newConfig, godi.WithMatch(iface)),
+		godi.NewDependency(newConfig, godi.WithName("a"), godi.WithGroup("b")), // want `WithName cannot be used with WithGroup`
+		godi.NewDependency(newRunnable, godi.WithGroup("runners")),             // want `Runnable cannot be used with WithGroup`
+		godi.NewDependency(newRunnable, godi.WithName("runner")),               // want `WithName cannot be used with Runnable`
+		godi.Replace(newConfig, godi.WithGroup("configs")),                     // want `godi.Replace is not supported for group dependencies`
+		godi.Decorate(newConfig, godi.WithGroup("configs")),                    // want `godi.Decorate does not support WithGroup`
+		godi.Decorate(newConfig, godi.WithName("primary")),                     // want `godi.Decorate does not support WithName`
+	)
+}
+
+func matchings() []godi.ContainerOption {
+	return []godi.ContainerOption{
+		godi.WithMatchings(godi.NewMatching(&reader{}, (*io.Reader)(nil), reader{})), // want `godi.NewMatching expects a pointer to an interface`
+	}
+}
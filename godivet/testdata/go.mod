@@ -0,0 +1,10 @@
+module example.com/vet
+
+go 1.25.4
+
+require (
+	github.com/assurrussa/godi v0.0.0
+	go.uber.org/dig v1.19.0
+)
+
+replace github.com/assurrussa/godi => ../..
@@ -12,38 +12,45 @@ import (
 type Graph struct {
 	Providers []ProviderNode
 	Edges     []ProviderEdge
+	// Overrides lists the slot overrides of the scope, as reported by DetectOverrides or Container.Overrides.
+	Overrides []OverrideInfo
 }
 
 type ProviderNode struct {
-	ID          string
-	Key         string
-	Type        string
-	Name        string
-	Group       string
-	Kind        string
-	Constructor string
-	File        string
-	Line        int
-	Provides    []GraphToken
-	Requires    []GraphToken
+	ID          string       `json:"id"`
+	Key         string       `json:"key,omitempty"`
+	Type        string       `json:"type"`
+	Name        string       `json:"name,omitempty"`
+	Group       string       `json:"group,omitempty"`
+	Kind        string       `json:"kind"`
+	Module      string       `json:"module,omitempty"`
+	Private     bool         `json:"private,omitempty"`
+	Constructor string       `json:"constructor,omitempty"`
+	File        string       `json:"file,omitempty"`
+	Line        int          `json:"line,omitempty"`
+	Provides    []GraphToken `json:"provides"`
+	Requires    []GraphToken `json:"requires"`
 }
 
 type GraphToken struct {
 	typ      reflect.Type
-	Type     string
-	Name     string
-	Group    string
-	Optional bool
+	lazyType reflect.Type
+	Type     string `json:"type"`
+	Name     string `json:"name,omitempty"`
+	Group    string `json:"group,omitempty"`
+	Optional bool   `json:"optional,omitempty"`
+	Lazy     bool   `json:"lazy,omitempty"`
 }
 
 type ProviderEdge struct {
-	From     string
-	To       string
-	Type     string
-	Name     string
-	Group    string
-	Optional bool
-	Missing  bool
+	From     string `json:"from"`
+	To       string `json:"to,omitempty"`
+	Type     string `json:"type"`
+	Name     string `json:"name,omitempty"`
+	Group    string `json:"group,omitempty"`
+	Optional bool   `json:"optional,omitempty"`
+	Lazy     bool   `json:"lazy,omitempty"`
+	Missing  bool   `json:"missing,omitempty"`
 }
 
 type tokenKey struct {
@@ -55,7 +62,7 @@ type tokenKey struct {
 // Graph builds a dependency graph for the container root scope (resolved providers only).
 func (c *Container) Graph() Graph {
 	graphs := c.GraphModules()
-	if graph, ok := graphs["root"]; ok {
+	if graph, ok := graphs[rootScopeName]; ok {
 		return graph
 	}
 	return BuildGraph(CollectDependencies(c.dependencies...))
@@ -72,17 +79,32 @@ func (c *Container) GraphModules() map[string]Graph {
 	rootEntries := buildRootEntries(c.dependencies)
 	moduleResolutions, err := buildModuleResolutions(c.modules)
 	if err != nil {
-		return map[string]Graph{"root": BuildGraph(CollectDependencies(c.dependencies...))}
+		return map[string]Graph{rootScopeName: BuildGraph(CollectDependencies(c.dependencies...))}
 	}
 
 	globalEntries := buildGlobalEntries(rootEntries, moduleResolutions)
 	globalResolution, err := resolveEntries(globalEntries)
 	if err != nil {
-		return map[string]Graph{"root": BuildGraph(CollectDependencies(c.dependencies...))}
+		return map[string]Graph{rootScopeName: BuildGraph(CollectDependencies(c.dependencies...))}
 	}
 
+	graphs := scopeGraphs(globalResolution, moduleResolutions)
+	overrides := map[string][]OverrideInfo{}
+	for _, override := range c.Overrides() {
+		overrides[override.Scope] = append(overrides[override.Scope], override)
+	}
+	for scope, graph := range graphs {
+		graph.Overrides = overrides[scope]
+		graphs[scope] = graph
+	}
+
+	return graphs
+}
+
+// scopeGraphs builds the root graph and one graph per module from resolved scopes.
+func scopeGraphs(globalResolution resolvedScope, moduleResolutions map[string]resolvedScope) map[string]Graph {
 	graphs := map[string]Graph{}
-	graphs["root"] = buildGraphFromEntries(globalResolution.providers, globalResolution.decorators)
+	graphs[rootScopeName] = buildGraphFromEntries(globalResolution.providers, globalResolution.decorators)
 
 	for moduleName, res := range moduleResolutions {
 		entries := moduleGraphEntries(globalResolution.providers, res.providers)
@@ -96,7 +118,6 @@ func (c *Container) GraphModules() map[string]Graph {
 		}
 		graphs[moduleName] = buildGraphFromEntries(resolved.providers, decorators)
 	}
-
 	return graphs
 }
 
@@ -110,21 +131,21 @@ func (c *Container) GraphDOTModules() map[string]string {
 	return dots
 }
 
-// BuildGraph builds a dependency graph for the provided dependencies (resolved providers only).
+// BuildGraph builds a dependency graph for the provided dependencies (resolved providers only) with the
+// replacements reported by DetectOverrides.
 func BuildGraph(deps Dependencies) Graph {
-	list := deps.List()
-	entries := make([]depEntry, 0, len(list))
-	for i, dep := range list {
-		entries = append(entries, depEntry{dep: dep, idx: i})
-	}
+	entries := buildRootEntries(deps.List())
 
+	var graph Graph
 	resolved, err := resolveEntries(entries)
 	if err != nil {
 		providers, decorators := splitEntries(entries)
-		return buildGraphFromEntries(providers, decorators)
+		graph = buildGraphFromEntries(providers, decorators)
+	} else {
+		graph = buildGraphFromEntries(resolved.providers, resolved.decorators)
 	}
-
-	return buildGraphFromEntries(resolved.providers, resolved.decorators)
+	graph.Overrides = detectReplacements(entries, rootScopeName)
+	return graph
 }
 
 func buildGraphFromEntries(providerEntries []depEntry, decoratorEntries []depEntry) Graph {
@@ -196,7 +217,7 @@ func buildDecoratorNodes(entries []depEntry) ([]ProviderNode, map[slotKey][]stri
 func buildNodeFromEntry(entry depEntry) (ProviderNode, string) {
 	dep := entry.dep
 	info := describeProvider(dep, entry.idx)
-	id := buildProviderID(dep, info, entry.idx)
+	id := entry.id
 	node := ProviderNode{
 		ID:          id,
 		Key:         derefString(dep.key),
@@ -204,6 +225,8 @@ func buildNodeFromEntry(entry depEntry) (ProviderNode, string) {
 		Name:        derefString(dep.name),
 		Group:       depGroup(dep),
 		Kind:        dependencyKindString(dep.kind),
+		Module:      entry.module,
+		Private:     dep.private,
 		Constructor: info.Constructor,
 		File:        info.File,
 		Line:        info.Line,
@@ -268,6 +291,7 @@ func buildGraphEdges(
 					Name:     token.Name,
 					Group:    token.Group,
 					Optional: token.Optional,
+					Lazy:     token.Lazy,
 					Missing:  true,
 				})
 				continue
@@ -281,6 +305,7 @@ func buildGraphEdges(
 					Name:     token.Name,
 					Group:    token.Group,
 					Optional: token.Optional,
+					Lazy:     token.Lazy,
 				})
 			}
 		}
@@ -335,87 +360,92 @@ func (g Graph) DOT() string {
 	_, _ = b.WriteString("  node [fontname=\"Helvetica\"];\n")
 
 	for _, node := range g.Providers {
-		label := buildProviderLabel(node)
-		shape := "box"
-		style := "solid"
-		if node.Kind == dependencyKindString(dependencyKindDecorate) {
-			shape = "ellipse"
-			style = "dashed"
-		} else if node.Kind == dependencyKindString(dependencyKindReplace) {
-			style = "bold"
-		}
-		_, _ = b.WriteString(fmt.Sprintf(
-			"  \"%s\" [shape=%s style=%s label=\"%s\"];\n",
-			escapeDOT(node.ID),
-			shape,
-			style,
-			escapeDOT(label),
-		))
+		writeDOTNode(&b, "  ", node.ID, node, buildProviderLabel(node), "")
 	}
 
-	missingNodes := map[string]string{}
-	for _, edge := range g.Edges {
-		if !edge.Missing {
-			continue
-		}
-		id := missingNodeID(edge)
-		if _, exists := missingNodes[id]; exists {
-			continue
-		}
-		label := buildTokenLabel(GraphToken{
-			Type:     edge.Type,
-			Name:     edge.Name,
-			Group:    edge.Group,
-			Optional: edge.Optional,
-		})
-		missingNodes[id] = label
-	}
-	for id, label := range missingNodes {
-		_, _ = b.WriteString(fmt.Sprintf(
-			"  \"%s\" [shape=diamond style=dashed label=\"%s\"];\n",
-			escapeDOT(id),
-			escapeDOT(label),
-		))
+	for _, missing := range g.missingNodes() {
+		writeDOTMissingNode(&b, missing)
 	}
 
+	cycleEdges := g.cycleEdges()
 	for _, edge := range g.Edges {
-		label := buildTokenLabel(GraphToken{
-			Type:     edge.Type,
-			Name:     edge.Name,
-			Group:    edge.Group,
-			Optional: edge.Optional,
-		})
 		target := edge.To
 		if edge.Missing {
 			target = missingNodeID(edge)
 		}
-		style := "solid"
-		if edge.Optional {
-			style = "dashed"
-		}
-		_, _ = b.WriteString(fmt.Sprintf(
-			"  \"%s\" -> \"%s\" [label=\"%s\" style=%s];\n",
-			escapeDOT(edge.From),
-			escapeDOT(target),
-			escapeDOT(label),
-			style,
-		))
+		writeDOTEdge(&b, edge.From, target, edge, !edge.Missing && cycleEdges[edge.From+"|"+edge.To])
 	}
 
 	_, _ = b.WriteString("}\n")
 	return b.String()
 }
 
-func buildProviderID(dep Dependency, info ProviderInfo, index int) string {
+func writeDOTNode(b *strings.Builder, indent, id string, node ProviderNode, label, extra string) {
+	shape := "box"
+	style := "solid"
+	if node.Kind == dependencyKindString(dependencyKindDecorate) {
+		shape = "ellipse"
+		style = "dashed"
+	} else if node.Kind == dependencyKindString(dependencyKindReplace) {
+		style = "bold"
+	}
+	_, _ = b.WriteString(fmt.Sprintf(
+		"%s\"%s\" [shape=%s style=%s%s label=\"%s\"];\n",
+		indent,
+		escapeDOT(id),
+		shape,
+		style,
+		extra,
+		escapeDOT(label),
+	))
+}
+
+func writeDOTMissingNode(b *strings.Builder, missing missingNode) {
+	_, _ = b.WriteString(fmt.Sprintf(
+		"  \"%s\" [shape=diamond style=dashed label=\"%s\"];\n",
+		escapeDOT(missing.id),
+		escapeDOT(missing.label),
+	))
+}
+
+func writeDOTEdge(b *strings.Builder, from, to string, edge ProviderEdge, inCycle bool) {
+	style := "solid"
+	if edge.Optional {
+		style = "dashed"
+	} else if edge.Lazy {
+		style = "dotted"
+	}
+	color := ""
+	if inCycle {
+		color = " color=red"
+	}
+	_, _ = b.WriteString(fmt.Sprintf(
+		"  \"%s\" -> \"%s\" [label=\"%s\" style=%s%s];\n",
+		escapeDOT(from),
+		escapeDOT(to),
+		escapeDOT(buildEdgeLabel(edge)),
+		style,
+		color,
+	))
+}
+
+// buildProviderID identifies a provider by its key, or by its constructor and name or group, so the ID does
+// not change when other dependencies are added or reordered.
+func buildProviderID(dep Dependency, info ProviderInfo) string {
 	if dep.key != nil {
-		// Keys are often reused across Provide/Replace to keep diagnostics stable,
-		// so the ID must include the index to remain unique within the graph.
-		return fmt.Sprintf("key:%s#%d", *dep.key, index)
+		return "key:" + *dep.key
 	}
+	id := "dep:" + info.Type
 	if info.Constructor != "" {
-		return fmt.Sprintf("ctor:%s#%d", info.Constructor, index)
+		id = "ctor:" + info.Constructor
 	}
-	return fmt.Sprintf("dep:%d", index)
+	switch {
+	case info.Group != "":
+		id += "[group=" + info.Group + "]"
+	case info.Name != "":
+		id += "[name=" + info.Name + "]"
+	}
+	return id
 }
 
 func depTypeString(dep Dependency) string {
@@ -425,7 +455,52 @@ func depTypeString(dep Dependency) string {
 	return ""
 }
 
+// qualifiedNodeID makes a node ID unique across scopes, since entry indexes restart in every module.
+func qualifiedNodeID(node ProviderNode) string {
+	if node.Module == "" {
+		return node.ID
+	}
+	return node.Module + "/" + node.ID
+}
+
+type missingNode struct {
+	id    string
+	label string
+}
+
+// missingNodes returns one node per missing dependency token, in order of first appearance.
+func (g Graph) missingNodes() []missingNode {
+	seen := map[string]bool{}
+	nodes := make([]missingNode, 0)
+	for _, edge := range g.Edges {
+		if !edge.Missing {
+			continue
+		}
+		id := missingNodeID(edge)
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		nodes = append(nodes, missingNode{id: id, label: buildEdgeLabel(edge)})
+	}
+	return nodes
+}
+
+func buildEdgeLabel(edge ProviderEdge) string {
+	return buildTokenLabel(GraphToken{
+		Type:     edge.Type,
+		Name:     edge.Name,
+		Group:    edge.Group,
+		Optional: edge.Optional,
+		Lazy:     edge.Lazy,
+	})
+}
+
 func buildProviderLabel(node ProviderNode) string {
+	return strings.Join(providerLabelParts(node), "\\n")
+}
+
+func providerLabelParts(node ProviderNode) []string {
 	parts := []string{}
 	if node.Key != "" {
 		parts = append(parts, node.Key)
@@ -448,7 +523,37 @@ func buildProviderLabel(node ProviderNode) string {
 	if node.File != "" && node.Line > 0 {
 		parts = append(parts, fmt.Sprintf("%s:%d", node.File, node.Line))
 	}
-	return strings.Join(parts, "\\n")
+	return parts
+}
+
+// providerNodeLabel renders a short one-line description of a node for error messages.
+func providerNodeLabel(node ProviderNode) string {
+	label := node.Type
+	if node.Constructor != "" {
+		label = node.Constructor
+	}
+	if node.Module != "" {
+		label = node.Module + "/" + label
+	}
+	if node.File != "" && node.Line > 0 {
+		label = fmt.Sprintf("%s (%s:%d)", label, node.File, node.Line)
+	}
+	return label
+}
+
+// sortedScopeNames returns the keys of a per-scope map with the root scope first and modules by name.
+func sortedScopeNames[V any](scopes map[string]V) []string {
+	names := make([]string, 0, len(scopes))
+	for name := range scopes {
+		if name != rootScopeName {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if _, ok := scopes[rootScopeName]; ok {
+		names = append([]string{rootScopeName}, names...)
+	}
+	return names
 }
 
 func buildTokenLabel(token GraphToken) string {
@@ -465,6 +570,9 @@ func buildTokenLabel(token GraphToken) string {
 	if token.Optional {
 		parts = append(parts, "optional")
 	}
+	if token.Lazy {
+		parts = append(parts, "lazy")
+	}
 	return strings.Join(parts, " ")
 }
 
@@ -608,6 +716,10 @@ func parseConstructorInputs(constructor any) []GraphToken {
 			result = append(result, parseDigInFields(param)...)
 			continue
 		}
+		if elem, ok := lazyElemType(param); ok {
+			result = append(result, GraphToken{typ: elem, lazyType: param, Type: elem.String(), Lazy: true})
+			continue
+		}
 		result = append(result, GraphToken{typ: param, Type: param.String()})
 	}
 	return result
@@ -661,6 +773,18 @@ func parseDigInFields(t reflect.Type) []GraphToken {
 			fieldType = fieldType.Elem()
 		}
 
+		if elem, ok := lazyElemType(fieldType); ok && group == "" {
+			result = append(result, GraphToken{
+				typ:      elem,
+				lazyType: fieldType,
+				Type:     elem.String(),
+				Name:     name,
+				Optional: optional,
+				Lazy:     true,
+			})
+			continue
+		}
+
 		result = append(result, GraphToken{
 			typ:      fieldType,
 			Type:     fieldType.String(),
@@ -0,0 +1,121 @@
+package godi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GraphDOTCombined renders the whole application as one DOT document.
+// Root providers are drawn at the top level and every module is a "cluster_<name>" subgraph holding
+// its private providers, decorators and exported providers (double border). Each edge is drawn once,
+// resolved in the scope its consumer lives in, so cross-module edges connect clusters directly. Cycle edges
+// are red, as in GraphDOT.
+func (c *Container) GraphDOTCombined() string {
+	graphs := c.GraphModules()
+
+	homeScope := func(node ProviderNode) string {
+		if _, ok := graphs[node.Module]; ok && node.Module != "" {
+			return node.Module
+		}
+		return rootScopeName
+	}
+	// Cycle edges are looked up in the consumer's scope graph, as Graph.DOT does for a single scope.
+	cycleEdges := map[string]map[string]bool{}
+	inCycle := func(scope string, edge ProviderEdge) bool {
+		if edge.Missing {
+			return false
+		}
+		if _, ok := cycleEdges[scope]; !ok {
+			cycleEdges[scope] = graphs[scope].cycleEdges()
+		}
+		return cycleEdges[scope][edge.From+"|"+edge.To]
+	}
+
+	var b strings.Builder
+	_, _ = b.WriteString("digraph DI {\n")
+	_, _ = b.WriteString("  rankdir=LR;\n")
+	_, _ = b.WriteString("  compound=true;\n")
+	_, _ = b.WriteString("  node [fontname=\"Helvetica\"];\n")
+
+	nodes := make([]ProviderNode, 0)
+	seen := map[string]bool{}
+	collect := func(node ProviderNode) bool {
+		id := qualifiedNodeID(node)
+		if seen[id] {
+			return false
+		}
+		seen[id] = true
+		nodes = append(nodes, node)
+		return true
+	}
+
+	for _, node := range graphs[rootScopeName].Providers {
+		if node.Module == "" && collect(node) {
+			writeDOTNode(&b, "  ", qualifiedNodeID(node), node, buildProviderLabel(node), "")
+		}
+	}
+
+	for _, module := range c.modules {
+		graph, ok := graphs[module.Name]
+		if !ok {
+			continue
+		}
+		_, _ = b.WriteString(fmt.Sprintf("  subgraph \"cluster_%s\" {\n", escapeDOT(module.Name)))
+		_, _ = b.WriteString(fmt.Sprintf("    label=\"module %s\";\n", escapeDOT(module.Name)))
+		_, _ = b.WriteString("    style=rounded;\n")
+		for _, node := range graph.Providers {
+			if node.Module != module.Name || !collect(node) {
+				continue
+			}
+			label := buildProviderLabel(node)
+			extra := ""
+			switch {
+			case node.Private:
+				// Private providers are modeled as replacements in the module graph; show them as regular providers.
+				node.Kind = dependencyKindString(dependencyKindProvide)
+				label += "\\nprivate"
+			case node.Kind != dependencyKindString(dependencyKindDecorate):
+				extra = " peripheries=2"
+				label += "\\nexported"
+			}
+			writeDOTNode(&b, "    ", qualifiedNodeID(node), node, label, extra)
+		}
+		_, _ = b.WriteString("  }\n")
+	}
+
+	missingSeen := map[string]bool{}
+	for _, node := range nodes {
+		for _, edge := range graphs[homeScope(node)].Edges {
+			if edge.From != node.ID || !edge.Missing {
+				continue
+			}
+			missing := missingNode{id: missingNodeID(edge), label: buildEdgeLabel(edge)}
+			if !missingSeen[missing.id] {
+				missingSeen[missing.id] = true
+				writeDOTMissingNode(&b, missing)
+			}
+		}
+	}
+
+	for _, node := range nodes {
+		scope := homeScope(node)
+		graph := graphs[scope]
+		for _, edge := range graph.Edges {
+			if edge.From != node.ID {
+				continue
+			}
+			target := missingNodeID(edge)
+			if !edge.Missing {
+				targetNode, ok := findProvider(graph, edge.To)
+				if !ok {
+					continue
+				}
+				target = qualifiedNodeID(targetNode)
+			}
+			writeDOTEdge(&b, qualifiedNodeID(node), target, edge, inCycle(scope, edge))
+		}
+	}
+
+	_, _ = b.WriteString("}\n")
+	return b.String()
+}
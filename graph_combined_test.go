@@ -0,0 +1,54 @@
+package godi_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/assurrussa/godi"
+)
+
+func TestGraphDOTCombinedRendersModuleClusters(t *testing.T) {
+	t.Parallel()
+
+	users := godi.NewModule("users", godi.CollectDependencies(
+		godi.NewDependency(func() string { return "secret" }, godi.Private()),
+		godi.NewDependency(func(s string) int { return len(s) }),
+	))
+	billing := godi.NewModule("billing", godi.CollectDependencies(
+		godi.NewDependency(func(n int) float64 { return float64(n) }),
+	))
+
+	cnt, err := godi.NewContainer(
+		godi.WithDependencies(godi.NewSingleDependency(func(f float64) bool { return f > 0 })),
+		godi.WithModules(users, billing),
+	)
+	if err != nil {
+		t.Fatalf("NewContainer error: %v", err)
+	}
+
+	dot := cnt.GraphDOTCombined()
+	for _, want := range []string{
+		`subgraph "cluster_users"`,
+		`subgraph "cluster_billing"`,
+		`\nprivate`,
+		`peripheries=2`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Fatalf("expected combined DOT to contain %q, got:\n%s", want, dot)
+		}
+	}
+	if strings.Count(dot, "digraph") != 1 {
+		t.Fatalf("expected a single digraph, got:\n%s", dot)
+	}
+	if strings.Contains(dot, "missing:") {
+		t.Fatalf("expected module-private dependency to resolve inside its cluster, got:\n%s", dot)
+	}
+
+	// bool -> float64 (root -> billing), float64 -> int (billing -> users), int -> string (users private).
+	if n := strings.Count(dot, " -> "); n != 3 {
+		t.Fatalf("expected 3 edges drawn once each, got %d:\n%s", n, dot)
+	}
+	if !strings.Contains(dot, `"billing/`) || !strings.Contains(dot, `"users/`) {
+		t.Fatalf("expected module-qualified node IDs, got:\n%s", dot)
+	}
+}
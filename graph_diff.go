@@ -0,0 +1,326 @@
+package godi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GraphDiff describes wiring changes between two graphs.
+// Providers are matched by their module and ProviderNode.ID, which does not depend on the declaration order;
+// edges are matched by their endpoints and token, and overrides by scope, kind, slot and constructors.
+type GraphDiff struct {
+	AddedProviders   []ProviderNode
+	RemovedProviders []ProviderNode
+	ChangedProviders []ProviderChange
+	AddedEdges       []ProviderEdge
+	RemovedEdges     []ProviderEdge
+	ChangedEdges     []EdgeChange
+	AddedOverrides   []OverrideInfo
+	RemovedOverrides []OverrideInfo
+
+	before graphIndex
+	after  graphIndex
+}
+
+// ProviderChange is a provider present in both graphs whose inputs, outputs or visibility changed.
+type ProviderChange struct {
+	Key    string
+	Before ProviderNode
+	After  ProviderNode
+}
+
+// EdgeChange is an edge present in both graphs whose optional/lazy flags changed.
+type EdgeChange struct {
+	Key    string
+	Before ProviderEdge
+	After  ProviderEdge
+}
+
+type graphIndex struct {
+	graph     Graph
+	keyByID   map[string]string
+	nodeByKey map[string]ProviderNode
+	edgeByKey map[string]ProviderEdge
+}
+
+// DiffGraphs compares two graphs (for example two containers or two builds decoded from JSON).
+func DiffGraphs(a, b Graph) GraphDiff {
+	before := indexGraph(a)
+	after := indexGraph(b)
+	diff := GraphDiff{before: before, after: after}
+
+	for _, node := range a.Providers {
+		key := before.keyByID[node.ID]
+		next, ok := after.nodeByKey[key]
+		if !ok {
+			diff.RemovedProviders = append(diff.RemovedProviders, node)
+			continue
+		}
+		if providerChanged(node, next) {
+			diff.ChangedProviders = append(diff.ChangedProviders, ProviderChange{Key: key, Before: node, After: next})
+		}
+	}
+	for _, node := range b.Providers {
+		if _, ok := before.nodeByKey[after.keyByID[node.ID]]; !ok {
+			diff.AddedProviders = append(diff.AddedProviders, node)
+		}
+	}
+
+	for _, edge := range a.Edges {
+		key := before.edgeKey(edge)
+		next, ok := after.edgeByKey[key]
+		if !ok {
+			diff.RemovedEdges = append(diff.RemovedEdges, edge)
+			continue
+		}
+		if edge.Optional != next.Optional || edge.Lazy != next.Lazy {
+			diff.ChangedEdges = append(diff.ChangedEdges, EdgeChange{Key: key, Before: edge, After: next})
+		}
+	}
+	for _, edge := range b.Edges {
+		if _, ok := before.edgeByKey[after.edgeKey(edge)]; !ok {
+			diff.AddedEdges = append(diff.AddedEdges, edge)
+		}
+	}
+
+	beforeOverrides := overrideKeys(a.Overrides)
+	afterOverrides := overrideKeys(b.Overrides)
+	for _, override := range a.Overrides {
+		if !afterOverrides[overrideKey(override)] {
+			diff.RemovedOverrides = append(diff.RemovedOverrides, override)
+		}
+	}
+	for _, override := range b.Overrides {
+		if !beforeOverrides[overrideKey(override)] {
+			diff.AddedOverrides = append(diff.AddedOverrides, override)
+		}
+	}
+
+	return diff
+}
+
+// Empty reports whether the graphs are equivalent.
+func (d GraphDiff) Empty() bool {
+	return len(d.AddedProviders) == 0 && len(d.RemovedProviders) == 0 && len(d.ChangedProviders) == 0 &&
+		len(d.AddedEdges) == 0 && len(d.RemovedEdges) == 0 && len(d.ChangedEdges) == 0 &&
+		len(d.AddedOverrides) == 0 && len(d.RemovedOverrides) == 0
+}
+
+// String renders the diff as text: "+" added, "-" removed, "~" changed.
+func (d GraphDiff) String() string {
+	var b strings.Builder
+	for _, node := range d.AddedProviders {
+		_, _ = b.WriteString("+ provider " + providerNodeLabel(node) + "\n")
+	}
+	for _, node := range d.RemovedProviders {
+		_, _ = b.WriteString("- provider " + providerNodeLabel(node) + "\n")
+	}
+	for _, change := range d.ChangedProviders {
+		_, _ = b.WriteString(fmt.Sprintf(
+			"~ provider %s: requires [%s] -> [%s], provides [%s] -> [%s]\n",
+			providerNodeLabel(change.After),
+			tokenListLabel(change.Before.Requires),
+			tokenListLabel(change.After.Requires),
+			tokenListLabel(change.Before.Provides),
+			tokenListLabel(change.After.Provides),
+		))
+	}
+	for _, edge := range d.AddedEdges {
+		_, _ = b.WriteString("+ edge " + d.after.edgeLabel(edge) + "\n")
+	}
+	for _, edge := range d.RemovedEdges {
+		_, _ = b.WriteString("- edge " + d.before.edgeLabel(edge) + "\n")
+	}
+	for _, change := range d.ChangedEdges {
+		_, _ = b.WriteString(fmt.Sprintf(
+			"~ edge %s (was %s)\n",
+			d.after.edgeLabel(change.After),
+			buildEdgeLabel(change.Before),
+		))
+	}
+	for _, override := range d.AddedOverrides {
+		_, _ = b.WriteString("+ override " + overrideLabel(override) + "\n")
+	}
+	for _, override := range d.RemovedOverrides {
+		_, _ = b.WriteString("- override " + overrideLabel(override) + "\n")
+	}
+	return b.String()
+}
+
+// DOT renders the provider and edge changes in DOT format: added elements green, removed red, changed orange.
+// Unchanged providers are drawn in gray only when a changed edge touches them.
+func (d GraphDiff) DOT() string {
+	var b strings.Builder
+	_, _ = b.WriteString("digraph DIDiff {\n")
+	_, _ = b.WriteString("  rankdir=LR;\n")
+	_, _ = b.WriteString("  node [fontname=\"Helvetica\"];\n")
+
+	colors := map[string]string{}
+	nodes := map[string]ProviderNode{}
+	order := make([]string, 0)
+	mark := func(key string, node ProviderNode, color string) {
+		if _, ok := nodes[key]; !ok {
+			order = append(order, key)
+		}
+		nodes[key] = node
+		if color != "" || colors[key] == "" {
+			colors[key] = color
+		}
+	}
+
+	for _, node := range d.AddedProviders {
+		mark(d.after.keyByID[node.ID], node, "green")
+	}
+	for _, node := range d.RemovedProviders {
+		mark(d.before.keyByID[node.ID], node, "red")
+	}
+	for _, change := range d.ChangedProviders {
+		mark(change.Key, change.After, "orange")
+	}
+
+	type diffEdge struct {
+		from, to string
+		edge     ProviderEdge
+		color    string
+	}
+	edges := make([]diffEdge, 0)
+	addEdge := func(index graphIndex, edge ProviderEdge, color string) {
+		from := index.keyByID[edge.From]
+		if _, ok := nodes[from]; !ok {
+			mark(from, index.nodeByKey[from], "")
+		}
+		to := missingNodeID(edge)
+		if !edge.Missing {
+			to = index.keyByID[edge.To]
+			if _, ok := nodes[to]; !ok {
+				mark(to, index.nodeByKey[to], "")
+			}
+		}
+		edges = append(edges, diffEdge{from: from, to: to, edge: edge, color: color})
+	}
+	for _, edge := range d.AddedEdges {
+		addEdge(d.after, edge, "green")
+	}
+	for _, edge := range d.RemovedEdges {
+		addEdge(d.before, edge, "red")
+	}
+	for _, change := range d.ChangedEdges {
+		addEdge(d.after, change.After, "orange")
+	}
+
+	for _, key := range order {
+		color := colors[key]
+		if color == "" {
+			color = "gray"
+		}
+		writeDOTNode(&b, "  ", key, nodes[key], buildProviderLabel(nodes[key]), " color="+color)
+	}
+	missingSeen := map[string]bool{}
+	for _, e := range edges {
+		if e.edge.Missing && !missingSeen[e.to] {
+			missingSeen[e.to] = true
+			writeDOTMissingNode(&b, missingNode{id: e.to, label: buildEdgeLabel(e.edge)})
+		}
+	}
+	for _, e := range edges {
+		style := "solid"
+		if e.edge.Optional {
+			style = "dashed"
+		} else if e.edge.Lazy {
+			style = "dotted"
+		}
+		_, _ = b.WriteString(fmt.Sprintf(
+			"  \"%s\" -> \"%s\" [label=\"%s\" style=%s color=%s fontcolor=%s];\n",
+			escapeDOT(e.from),
+			escapeDOT(e.to),
+			escapeDOT(buildEdgeLabel(e.edge)),
+			style,
+			e.color,
+			e.color,
+		))
+	}
+
+	_, _ = b.WriteString("}\n")
+	return b.String()
+}
+
+func indexGraph(g Graph) graphIndex {
+	index := graphIndex{
+		graph:     g,
+		keyByID:   map[string]string{},
+		nodeByKey: map[string]ProviderNode{},
+		edgeByKey: map[string]ProviderEdge{},
+	}
+
+	for _, node := range g.Providers {
+		key := qualifiedNodeID(node)
+		index.keyByID[node.ID] = key
+		index.nodeByKey[key] = node
+	}
+	for _, edge := range g.Edges {
+		index.edgeByKey[index.edgeKey(edge)] = edge
+	}
+	return index
+}
+
+func (i graphIndex) edgeKey(edge ProviderEdge) string {
+	to := ""
+	if !edge.Missing {
+		to = i.keyByID[edge.To]
+	}
+	return strings.Join([]string{
+		i.keyByID[edge.From], to, edge.Type, edge.Name, edge.Group, strconv.FormatBool(edge.Missing),
+	}, "->")
+}
+
+func (i graphIndex) edgeLabel(edge ProviderEdge) string {
+	from := providerNodeLabel(i.nodeByKey[i.keyByID[edge.From]])
+	to := "missing"
+	if !edge.Missing {
+		to = providerNodeLabel(i.nodeByKey[i.keyByID[edge.To]])
+	}
+	return fmt.Sprintf("%s -> %s [%s]", from, to, buildEdgeLabel(edge))
+}
+
+func providerChanged(a, b ProviderNode) bool {
+	return a.Private != b.Private ||
+		tokenListLabel(a.Provides) != tokenListLabel(b.Provides) ||
+		tokenListLabel(a.Requires) != tokenListLabel(b.Requires)
+}
+
+func tokenListLabel(tokens []GraphToken) string {
+	labels := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		labels = append(labels, buildTokenLabel(token))
+	}
+	return strings.Join(labels, ", ")
+}
+
+// overrideKey identifies an override by scope, kind, slot and the constructors involved; indexes and
+// source lines are left out, so reordering or moving code is not a change.
+func overrideKey(o OverrideInfo) string {
+	return strings.Join([]string{
+		o.Scope, o.Kind, o.Key,
+		o.Previous.Module, o.Previous.Constructor, o.Next.Module, o.Next.Constructor,
+	}, "|")
+}
+
+func overrideKeys(overrides []OverrideInfo) map[string]bool {
+	keys := make(map[string]bool, len(overrides))
+	for _, override := range overrides {
+		keys[overrideKey(override)] = true
+	}
+	return keys
+}
+
+func overrideLabel(o OverrideInfo) string {
+	return fmt.Sprintf("%s %s in %s: %s -> %s",
+		o.Kind, o.Key, o.Scope, providerInfoLabel(overrideSide(o.Previous)), providerInfoLabel(overrideSide(o.Next)))
+}
+
+// overrideSide drops the source location, which the label does not need.
+func overrideSide(info ProviderInfo) ProviderInfo {
+	info.File, info.Line = "", 0
+	return info
+}
@@ -0,0 +1,195 @@
+package godi_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/assurrussa/godi"
+)
+
+type (
+	diffConfig struct{}
+	diffDB     struct{}
+	diffCache  struct{}
+	diffRepo   struct{}
+)
+
+func newDiffConfig() *diffConfig                      { return &diffConfig{} }
+func newDiffDB(*diffConfig) *diffDB                   { return &diffDB{} }
+func newDiffCache() *diffCache                        { return &diffCache{} }
+func newDiffRepo(*diffDB) *diffRepo                   { return &diffRepo{} }
+func newDiffRepoCached(*diffDB, *diffCache) *diffRepo { return &diffRepo{} }
+
+func TestDiffGraphsIdentical(t *testing.T) {
+	t.Parallel()
+
+	deps := godi.CollectDependencies(
+		godi.NewDependency(newDiffConfig),
+		godi.NewDependency(newDiffDB),
+		godi.NewDependency(newDiffRepo),
+	)
+	diff := godi.DiffGraphs(godi.BuildGraph(deps), godi.BuildGraph(deps))
+	if !diff.Empty() {
+		t.Fatalf("expected empty diff, got:\n%s", diff.String())
+	}
+}
+
+func TestDiffGraphsProvidersAndEdges(t *testing.T) {
+	t.Parallel()
+
+	before := godi.BuildGraph(godi.CollectDependencies(
+		godi.NewDependency(newDiffConfig),
+		godi.NewDependency(newDiffDB),
+		godi.NewDependency(newDiffRepo),
+	))
+	// The cache is inserted first; provider IDs do not depend on the declaration index.
+	after := godi.BuildGraph(godi.CollectDependencies(
+		godi.NewDependency(newDiffCache),
+		godi.NewDependency(newDiffDB),
+		godi.NewDependency(newDiffRepoCached),
+	))
+
+	diff := godi.DiffGraphs(before, after)
+	if len(diff.AddedProviders) != 2 || len(diff.RemovedProviders) != 2 {
+		t.Fatalf("expected 2 added and 2 removed providers, got:\n%s", diff.String())
+	}
+	if len(diff.ChangedProviders) != 0 {
+		t.Fatalf("expected no changed providers, got %+v", diff.ChangedProviders)
+	}
+
+	text := diff.String()
+	for _, want := range []string{"+ provider", "- provider", "+ edge", "- edge"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in diff:\n%s", want, text)
+		}
+	}
+	if !strings.Contains(text, "missing") {
+		t.Fatalf("expected the new missing config edge in diff:\n%s", text)
+	}
+
+	dot := diff.DOT()
+	if !strings.HasPrefix(dot, "digraph DIDiff {") {
+		t.Fatalf("unexpected DOT header:\n%s", dot)
+	}
+	for _, want := range []string{"color=green", "color=red", "color=gray", "shape=diamond"} {
+		if !strings.Contains(dot, want) {
+			t.Fatalf("expected %q in DOT:\n%s", want, dot)
+		}
+	}
+}
+
+func TestDiffGraphsChanges(t *testing.T) {
+	t.Parallel()
+
+	// Graphs decoded from two builds: the repository's DB dependency became optional.
+	graph := func(optional bool) godi.Graph {
+		repo := godi.ProviderNode{ID: "ctor:newRepo#1", Kind: "provide", Constructor: "app.newRepo", Type: "*app.Repo"}
+		if optional {
+			repo.Requires = []godi.GraphToken{{Type: "*app.DB", Optional: true}}
+		} else {
+			repo.Requires = []godi.GraphToken{{Type: "*app.DB"}}
+		}
+		return godi.Graph{
+			Providers: []godi.ProviderNode{
+				{ID: "ctor:newDB#0", Kind: "provide", Constructor: "app.newDB", Type: "*app.DB"},
+				repo,
+			},
+			Edges: []godi.ProviderEdge{
+				{From: "ctor:newRepo#1", To: "ctor:newDB#0", Type: "*app.DB", Optional: optional},
+			},
+		}
+	}
+
+	diff := godi.DiffGraphs(graph(false), graph(true))
+	if len(diff.ChangedEdges) != 1 || !diff.ChangedEdges[0].After.Optional {
+		t.Fatalf("expected one changed edge, got:\n%s", diff.String())
+	}
+	if len(diff.ChangedProviders) != 1 || diff.ChangedProviders[0].After.Type != "*app.Repo" {
+		t.Fatalf("expected the repository to change, got:\n%s", diff.String())
+	}
+	if len(diff.AddedEdges) != 0 || len(diff.RemovedEdges) != 0 {
+		t.Fatalf("expected no added or removed edges, got:\n%s", diff.String())
+	}
+	if !strings.Contains(diff.String(), "~ provider") || !strings.Contains(diff.String(), "~ edge") {
+		t.Fatalf("expected changes in text diff:\n%s", diff.String())
+	}
+	if !strings.Contains(diff.DOT(), "style=dashed color=orange") {
+		t.Fatalf("expected dashed orange edge in DOT:\n%s", diff.DOT())
+	}
+}
+
+func TestDiffGraphsFromJSON(t *testing.T) {
+	t.Parallel()
+
+	g := godi.BuildGraph(godi.CollectDependencies(
+		godi.NewDependency(newDiffConfig),
+		godi.NewDependency(newDiffDB),
+	))
+	data, err := g.JSON()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded godi.Graph
+	if err := decoded.UnmarshalJSON(data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diff := godi.DiffGraphs(g, decoded); !diff.Empty() {
+		t.Fatalf("expected empty diff after JSON round trip, got:\n%s", diff.String())
+	}
+}
+
+func TestDiffGraphsStableIDsAcrossReordering(t *testing.T) {
+	t.Parallel()
+
+	before := godi.BuildGraph(godi.CollectDependencies(
+		godi.NewDependency(newDiffConfig),
+		godi.NewDependency(newDiffDB),
+	))
+	after := godi.BuildGraph(godi.CollectDependencies(
+		godi.NewDependency(newDiffDB),
+		godi.NewDependency(newDiffConfig),
+	))
+
+	if diff := godi.DiffGraphs(before, after); !diff.Empty() {
+		t.Fatalf("expected reordering to be no change, got:\n%s", diff.String())
+	}
+	ids := map[string]bool{}
+	for _, node := range after.Providers {
+		ids[node.ID] = true
+	}
+	for _, node := range before.Providers {
+		if !ids[node.ID] {
+			t.Fatalf("expected provider ID %q to survive reordering, got %v", node.ID, after.ProviderIDs())
+		}
+	}
+}
+
+func TestDiffGraphsOverrides(t *testing.T) {
+	t.Parallel()
+
+	base := []godi.Dependency{
+		godi.NewDependency(newDiffConfig),
+		godi.NewDependency(newDiffDB),
+		godi.NewDependency(newDiffRepo),
+	}
+	before := godi.BuildGraph(godi.CollectDependencies(base...))
+	after := godi.BuildGraph(godi.CollectDependencies(append(base, godi.Replace(newDiffRepoCached))...))
+	if len(after.Overrides) != 1 {
+		t.Fatalf("expected the replacement in the graph overrides, got %+v", after.Overrides)
+	}
+
+	diff := godi.DiffGraphs(before, after)
+	if len(diff.AddedOverrides) != 1 || len(diff.RemovedOverrides) != 0 {
+		t.Fatalf("expected 1 added override, got %+v / %+v", diff.AddedOverrides, diff.RemovedOverrides)
+	}
+	text := diff.String()
+	if !strings.Contains(text, "+ override replace *godi_test.diffRepo in root:") ||
+		!strings.Contains(text, "newDiffRepoCached") {
+		t.Fatalf("expected added override in diff:\n%s", text)
+	}
+
+	if reverse := godi.DiffGraphs(after, before); len(reverse.RemovedOverrides) != 1 {
+		t.Fatalf("expected 1 removed override, got:\n%s", reverse.String())
+	}
+}
@@ -0,0 +1,24 @@
+package godi
+
+import (
+	"bytes"
+	_ "embed"
+)
+
+//go:embed graph_viewer.html
+var graphViewerHTML []byte
+
+// graphDataPlaceholder marks where the JSON document is embedded in graph_viewer.html.
+var graphDataPlaceholder = []byte("/*GRAPH_DATA*/")
+
+// HTML renders the graph as a self-contained HTML page (inline CSS and JS, works offline)
+// with search, module filtering, expandable dependencies and dependents, and source file links.
+// The page embeds the document produced by MarshalJSON.
+func (g Graph) HTML() ([]byte, error) {
+	// json.Marshal escapes <, > and &, so the document cannot terminate the surrounding script element.
+	data, err := g.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return bytes.Replace(graphViewerHTML, graphDataPlaceholder, data, 1), nil
+}
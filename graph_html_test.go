@@ -0,0 +1,47 @@
+package godi_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/assurrussa/godi"
+)
+
+func TestGraphHTML(t *testing.T) {
+	t.Parallel()
+
+	g := queryGraph()
+	page, err := g.HTML()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	html := string(page)
+	if !strings.HasPrefix(html, "<!DOCTYPE html>") {
+		t.Fatalf("expected an HTML document, got:\n%.200s", html)
+	}
+	if strings.Contains(html, "/*GRAPH_DATA*/") {
+		t.Fatal("expected graph data to replace the placeholder")
+	}
+	if strings.Contains(html, "<script src=") || strings.Contains(html, "http://") || strings.Contains(html, "https://") {
+		t.Fatal("expected a self-contained page without external resources")
+	}
+	for _, want := range []string{`"version":1`, `"*godi_test.queryServer"`, `"file":`} {
+		if !strings.Contains(html, want) {
+			t.Fatalf("expected %s in embedded graph data", want)
+		}
+	}
+}
+
+func TestGraphHTMLEscapesScriptContent(t *testing.T) {
+	t.Parallel()
+
+	g := godi.Graph{Providers: []godi.ProviderNode{{ID: "x", Type: "</script><b>", Kind: "provide"}}}
+	page, err := g.HTML()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(page), "</script><b>") {
+		t.Fatal("expected embedded data to be escaped")
+	}
+}
@@ -0,0 +1,106 @@
+package godi
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// GraphSchemaVersion is the version of the JSON document produced by Graph.JSON and Container.GraphJSON.
+// It is incremented on incompatible schema changes.
+const GraphSchemaVersion = 1
+
+type graphDocument struct {
+	Version    int            `json:"version"`
+	Modules    []string       `json:"modules"`
+	Providers  []ProviderNode `json:"providers"`
+	Decorators []ProviderNode `json:"decorators"`
+	Edges      []ProviderEdge `json:"edges"`
+	Overrides  []OverrideInfo `json:"overrides"`
+}
+
+type containerGraphDocument struct {
+	Version   int              `json:"version"`
+	Modules   []string         `json:"modules"`
+	Scopes    map[string]Graph `json:"scopes"`
+	Overrides []OverrideInfo   `json:"overrides"`
+}
+
+// JSON renders the graph as an indented JSON document (see MarshalJSON for the schema).
+func (g Graph) JSON() ([]byte, error) {
+	return json.MarshalIndent(g, "", "  ")
+}
+
+// MarshalJSON encodes the graph as a versioned document with providers and decorators split apart.
+func (g Graph) MarshalJSON() ([]byte, error) {
+	doc := graphDocument{
+		Version:    GraphSchemaVersion,
+		Modules:    make([]string, 0),
+		Providers:  make([]ProviderNode, 0, len(g.Providers)),
+		Decorators: make([]ProviderNode, 0),
+		Edges:      g.Edges,
+		Overrides:  g.Overrides,
+	}
+	if doc.Edges == nil {
+		doc.Edges = make([]ProviderEdge, 0)
+	}
+	if doc.Overrides == nil {
+		doc.Overrides = make([]OverrideInfo, 0)
+	}
+
+	seenModules := map[string]bool{}
+	for _, node := range g.Providers {
+		if node.Provides == nil {
+			node.Provides = make([]GraphToken, 0)
+		}
+		if node.Requires == nil {
+			node.Requires = make([]GraphToken, 0)
+		}
+		if node.Module != "" && !seenModules[node.Module] {
+			seenModules[node.Module] = true
+			doc.Modules = append(doc.Modules, node.Module)
+		}
+		if node.Kind == dependencyKindString(dependencyKindDecorate) {
+			doc.Decorators = append(doc.Decorators, node)
+			continue
+		}
+		doc.Providers = append(doc.Providers, node)
+	}
+
+	return json.Marshal(doc)
+}
+
+// UnmarshalJSON decodes a document produced by MarshalJSON. Documents of any other schema version, including
+// ones without a version, are rejected.
+// Reflection types are not restored, so decoded graphs are meant for rendering and diffing.
+func (g *Graph) UnmarshalJSON(data []byte) error {
+	var doc graphDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	if doc.Version != GraphSchemaVersion {
+		return fmt.Errorf("unsupported graph schema version %d (want %d)", doc.Version, GraphSchemaVersion)
+	}
+
+	g.Providers = append(doc.Providers, doc.Decorators...)
+	g.Edges = doc.Edges
+	g.Overrides = doc.Overrides
+	return nil
+}
+
+// GraphJSON renders the root and module graphs together with modules and overrides as one JSON document.
+func (c *Container) GraphJSON() ([]byte, error) {
+	return json.MarshalIndent(c.graphDocument(), "", "  ")
+}
+
+func (c *Container) graphDocument() containerGraphDocument {
+	doc := containerGraphDocument{
+		Version:   GraphSchemaVersion,
+		Modules:   make([]string, 0, len(c.modules)),
+		Scopes:    c.GraphModules(),
+		Overrides: c.Overrides(),
+	}
+	for _, module := range c.modules {
+		doc.Modules = append(doc.Modules, module.Name)
+	}
+	return doc
+}
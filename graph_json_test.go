@@ -0,0 +1,99 @@
+package godi_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/assurrussa/godi"
+)
+
+func TestGraphJSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	g := godi.BuildGraph(godi.CollectDependencies(
+		godi.NewDependency(func() string { return testBase }),
+		godi.Decorate(func(s string) string { return s + "!" }),
+		godi.NewDependency(func(string, int) bool { return true }),
+	))
+
+	data, err := g.JSON()
+	if err != nil {
+		t.Fatalf("JSON error: %v", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if doc["version"] != float64(godi.GraphSchemaVersion) {
+		t.Fatalf("expected schema version %d, got %v", godi.GraphSchemaVersion, doc["version"])
+	}
+	for _, key := range []string{"modules", "providers", "decorators", "edges"} {
+		if _, ok := doc[key].([]any); !ok {
+			t.Fatalf("expected %q to be an array, got %v", key, doc[key])
+		}
+	}
+	if n := len(doc["decorators"].([]any)); n != 1 {
+		t.Fatalf("expected 1 decorator, got %d", n)
+	}
+
+	var decoded godi.Graph
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if !reflect.DeepEqual(decoded.ProviderIDs(), g.ProviderIDs()) {
+		t.Fatalf("expected providers %v, got %v", g.ProviderIDs(), decoded.ProviderIDs())
+	}
+	if len(decoded.Edges) != len(g.Edges) {
+		t.Fatalf("expected %d edges, got %d", len(g.Edges), len(decoded.Edges))
+	}
+	for _, doc := range []string{`{"version":999}`, `{"version":0}`, `{"providers":[]}`} {
+		if err := json.Unmarshal([]byte(doc), &decoded); err == nil {
+			t.Fatalf("expected error for unsupported schema version in %s", doc)
+		}
+	}
+}
+
+func TestContainerGraphJSONIncludesModulesAndOverrides(t *testing.T) {
+	t.Parallel()
+
+	cnt, err := godi.NewContainer(
+		godi.WithDependencies(godi.CollectDependencies(
+			godi.NewDependency(func() string { return testBase }),
+			godi.Replace(func() string { return testOverride }),
+		)),
+		godi.WithModules(godi.NewModule("m", godi.NewSingleDependency(func() int { return 1 }))),
+	)
+	if err != nil {
+		t.Fatalf("NewContainer error: %v", err)
+	}
+
+	data, err := cnt.GraphJSON()
+	if err != nil {
+		t.Fatalf("GraphJSON error: %v", err)
+	}
+
+	var doc struct {
+		Version   int                   `json:"version"`
+		Modules   []string              `json:"modules"`
+		Scopes    map[string]godi.Graph `json:"scopes"`
+		Overrides []godi.OverrideInfo   `json:"overrides"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if len(doc.Modules) != 1 || doc.Modules[0] != "m" {
+		t.Fatalf("unexpected modules: %v", doc.Modules)
+	}
+	if _, ok := doc.Scopes["root"]; !ok {
+		t.Fatalf("expected root scope, got %v", doc.Scopes)
+	}
+	if len(doc.Overrides) != 1 || doc.Overrides[0].Kind != godi.OverrideKindReplace {
+		t.Fatalf("unexpected overrides: %+v", doc.Overrides)
+	}
+	if !strings.Contains(string(data), `"module": "m"`) {
+		t.Fatalf("expected module provider in JSON, got:\n%s", data)
+	}
+}
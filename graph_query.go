@@ -0,0 +1,144 @@
+package godi
+
+// Graph queries select nodes by ID or by provided type string (as in ProviderNode.Type or GraphToken.Type,
+// for example "*sql.DB"). An exact ID match takes precedence over a type match.
+// Edges point from a consumer to its dependency, and every query returns a new Graph built with Subgraph.
+
+// DependenciesOf returns the matched nodes and the providers they depend on directly.
+func (g Graph) DependenciesOf(query string) Graph {
+	ids := g.matchNodes(query)
+	selected := map[string]bool{}
+	for _, id := range ids {
+		selected[id] = true
+	}
+	for _, edge := range g.Edges {
+		if selected[edge.From] && !edge.Missing {
+			ids = append(ids, edge.To)
+		}
+	}
+	return g.subgraphWithEdges(ids, func(edge ProviderEdge) bool { return selected[edge.From] })
+}
+
+// DependentsOf returns the matched nodes and the providers that depend on them directly.
+func (g Graph) DependentsOf(query string) Graph {
+	ids := g.matchNodes(query)
+	selected := map[string]bool{}
+	for _, id := range ids {
+		selected[id] = true
+	}
+	for _, edge := range g.Edges {
+		if !edge.Missing && selected[edge.To] {
+			ids = append(ids, edge.From)
+		}
+	}
+	return g.subgraphWithEdges(ids, func(edge ProviderEdge) bool { return !edge.Missing && selected[edge.To] })
+}
+
+// TransitiveClosure returns the matched nodes and everything they depend on, directly or transitively.
+func (g Graph) TransitiveClosure(query string) Graph {
+	return g.Subgraph(g.reachable(g.matchNodes(query), false)...)
+}
+
+// TransitiveDependents returns the matched nodes and everything that depends on them, directly or transitively.
+// It answers "why is this provider constructed?".
+func (g Graph) TransitiveDependents(query string) Graph {
+	return g.Subgraph(g.reachable(g.matchNodes(query), true)...)
+}
+
+// PathsBetween returns the union of all dependency paths leading from nodes matching from to nodes matching to.
+func (g Graph) PathsBetween(from, to string) Graph {
+	forward := g.reachable(g.matchNodes(from), false)
+	backward := map[string]bool{}
+	for _, id := range g.reachable(g.matchNodes(to), true) {
+		backward[id] = true
+	}
+
+	ids := make([]string, 0)
+	for _, id := range forward {
+		if backward[id] {
+			ids = append(ids, id)
+		}
+	}
+	return g.subgraphWithEdges(ids, func(edge ProviderEdge) bool { return !edge.Missing })
+}
+
+// Subgraph returns the nodes with the given IDs, the edges between them and their missing dependencies.
+func (g Graph) Subgraph(ids ...string) Graph {
+	return g.subgraphWithEdges(ids, func(ProviderEdge) bool { return true })
+}
+
+func (g Graph) subgraphWithEdges(ids []string, keep func(edge ProviderEdge) bool) Graph {
+	selected := map[string]bool{}
+	for _, id := range ids {
+		selected[id] = true
+	}
+
+	result := Graph{Providers: make([]ProviderNode, 0), Edges: make([]ProviderEdge, 0)}
+	for _, node := range g.Providers {
+		if selected[node.ID] {
+			result.Providers = append(result.Providers, node)
+		}
+	}
+	for _, edge := range g.Edges {
+		if !selected[edge.From] || !keep(edge) {
+			continue
+		}
+		if edge.Missing || selected[edge.To] {
+			result.Edges = append(result.Edges, edge)
+		}
+	}
+	return result
+}
+
+func (g Graph) matchNodes(query string) []string {
+	for _, node := range g.Providers {
+		if node.ID == query {
+			return []string{node.ID}
+		}
+	}
+
+	ids := make([]string, 0)
+	for _, node := range g.Providers {
+		if node.Type == query {
+			ids = append(ids, node.ID)
+			continue
+		}
+		for _, token := range node.Provides {
+			if token.Type == query {
+				ids = append(ids, node.ID)
+				break
+			}
+		}
+	}
+	return ids
+}
+
+// reachable walks edges from the start nodes (towards dependencies, or towards dependents when reverse is set).
+func (g Graph) reachable(start []string, reverse bool) []string {
+	next := map[string][]string{}
+	for _, edge := range g.Edges {
+		if edge.Missing {
+			continue
+		}
+		if reverse {
+			next[edge.To] = append(next[edge.To], edge.From)
+		} else {
+			next[edge.From] = append(next[edge.From], edge.To)
+		}
+	}
+
+	visited := map[string]bool{}
+	result := make([]string, 0)
+	queue := append([]string{}, start...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+		result = append(result, id)
+		queue = append(queue, next[id]...)
+	}
+	return result
+}
@@ -0,0 +1,115 @@
+package godi_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/assurrussa/godi"
+)
+
+type (
+	queryDB     struct{}
+	queryRedis  struct{}
+	queryRepo   struct{}
+	queryCache  struct{}
+	queryServer struct{}
+)
+
+func queryGraph() godi.Graph {
+	return godi.BuildGraph(godi.CollectDependencies(
+		godi.NewDependency(func() *queryDB { return &queryDB{} }),
+		godi.NewDependency(func() *queryRedis { return &queryRedis{} }),
+		godi.NewDependency(func(*queryDB) *queryRepo { return &queryRepo{} }),
+		godi.NewDependency(func(*queryRedis, *queryRepo) *queryCache { return &queryCache{} }),
+		godi.NewDependency(func(*queryCache, *queryDB) *queryServer { return &queryServer{} }),
+	))
+}
+
+func graphTypes(g godi.Graph) []string {
+	out := make([]string, 0, len(g.Providers))
+	for _, p := range g.Providers {
+		out = append(out, p.Type)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func assertGraphTypes(t *testing.T, g godi.Graph, want ...string) {
+	t.Helper()
+	sort.Strings(want)
+	got := graphTypes(g)
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestGraphDependentsAndDependencies(t *testing.T) {
+	t.Parallel()
+
+	g := queryGraph()
+
+	dependents := g.DependentsOf("*godi_test.queryDB")
+	assertGraphTypes(t, dependents, "*godi_test.queryDB", "*godi_test.queryRepo", "*godi_test.queryServer")
+	if len(dependents.Edges) != 2 {
+		t.Fatalf("expected 2 edges into queryDB, got %+v", dependents.Edges)
+	}
+
+	deps := g.DependenciesOf("*godi_test.queryCache")
+	assertGraphTypes(t, deps, "*godi_test.queryCache", "*godi_test.queryRedis", "*godi_test.queryRepo")
+	if len(deps.Edges) != 2 {
+		t.Fatalf("expected 2 edges from queryCache, got %+v", deps.Edges)
+	}
+}
+
+func TestGraphTransitiveQueriesAndPaths(t *testing.T) {
+	t.Parallel()
+
+	g := queryGraph()
+
+	closure := g.TransitiveClosure("*godi_test.queryCache")
+	assertGraphTypes(t, closure, "*godi_test.queryCache", "*godi_test.queryRedis", "*godi_test.queryRepo", "*godi_test.queryDB")
+
+	why := g.TransitiveDependents("*godi_test.queryRedis")
+	assertGraphTypes(t, why, "*godi_test.queryRedis", "*godi_test.queryCache", "*godi_test.queryServer")
+
+	paths := g.PathsBetween("*godi_test.queryServer", "*godi_test.queryDB")
+	assertGraphTypes(t, paths,
+		"*godi_test.queryServer", "*godi_test.queryCache", "*godi_test.queryRepo", "*godi_test.queryDB")
+	if len(paths.Edges) != 4 {
+		t.Fatalf("expected 4 path edges, got %+v", paths.Edges)
+	}
+
+	if empty := g.PathsBetween("*godi_test.queryDB", "*godi_test.queryServer"); len(empty.Providers) != 0 {
+		t.Fatalf("expected no reverse paths, got %v", graphTypes(empty))
+	}
+}
+
+func TestGraphSubgraphByID(t *testing.T) {
+	t.Parallel()
+
+	g := queryGraph()
+	var repoID, dbID string
+	for _, p := range g.Providers {
+		switch p.Type {
+		case "*godi_test.queryRepo":
+			repoID = p.ID
+		case "*godi_test.queryDB":
+			dbID = p.ID
+		}
+	}
+
+	sub := g.Subgraph(repoID, dbID)
+	assertGraphTypes(t, sub, "*godi_test.queryRepo", "*godi_test.queryDB")
+	if len(sub.Edges) != 1 || sub.Edges[0].From != repoID || sub.Edges[0].To != dbID {
+		t.Fatalf("expected single repo -> db edge, got %+v", sub.Edges)
+	}
+
+	if byID := g.DependenciesOf(repoID); len(byID.Providers) != 2 {
+		t.Fatalf("expected ID query to match repo, got %v", graphTypes(byID))
+	}
+}
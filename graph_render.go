@@ -0,0 +1,158 @@
+package godi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Mermaid renders a dependency graph as a Mermaid flowchart.
+// Semantics match DOT: decorators are dashed ellipses, replacements are bold, optional edges are dashed,
+// lazy edges are dotted, missing dependencies are diamond nodes and cycle edges are red. Mermaid has a single
+// dotted arrow, so lazy edges get a finer dash pattern through linkStyle to tell them from optional ones.
+func (g Graph) Mermaid() string {
+	var b strings.Builder
+	_, _ = b.WriteString("flowchart LR\n")
+	_, _ = b.WriteString("  classDef decorate stroke-dasharray: 5 5;\n")
+	_, _ = b.WriteString("  classDef replace stroke-width: 3px;\n")
+	_, _ = b.WriteString("  classDef missing stroke-dasharray: 5 5;\n")
+
+	ids := g.renderNodeIDs()
+	for _, node := range g.Providers {
+		label := escapeMermaid(strings.Join(providerLabelParts(node), "<br/>"))
+		switch node.Kind {
+		case dependencyKindString(dependencyKindDecorate):
+			_, _ = b.WriteString(fmt.Sprintf("  %s([\"%s\"]):::decorate\n", ids[node.ID], label))
+		case dependencyKindString(dependencyKindReplace):
+			_, _ = b.WriteString(fmt.Sprintf("  %s[\"%s\"]:::replace\n", ids[node.ID], label))
+		default:
+			_, _ = b.WriteString(fmt.Sprintf("  %s[\"%s\"]\n", ids[node.ID], label))
+		}
+	}
+
+	for i, missing := range g.missingNodes() {
+		ids[missing.id] = fmt.Sprintf("m%d", i)
+		_, _ = b.WriteString(fmt.Sprintf("  %s{\"%s\"}:::missing\n", ids[missing.id], escapeMermaid(missing.label)))
+	}
+
+	cycleEdges := g.cycleEdges()
+	// linkStyle lines group edge indexes by style, in the order the styles first appear.
+	links := map[string][]string{}
+	linkStyles := make([]string, 0)
+	for i, edge := range g.Edges {
+		target := edge.To
+		if edge.Missing {
+			target = missingNodeID(edge)
+		}
+		arrow := "-->"
+		if edge.Optional || edge.Lazy {
+			arrow = "-.->"
+		}
+		_, _ = b.WriteString(fmt.Sprintf(
+			"  %s %s|\"%s\"| %s\n",
+			ids[edge.From],
+			arrow,
+			escapeMermaid(buildEdgeLabel(edge)),
+			ids[target],
+		))
+		styles := make([]string, 0, 2)
+		if !edge.Missing && cycleEdges[edge.From+"|"+edge.To] {
+			styles = append(styles, "stroke:red")
+		}
+		if edge.Lazy && !edge.Optional {
+			styles = append(styles, "stroke-dasharray:2 2")
+		}
+		if len(styles) > 0 {
+			style := strings.Join(styles, ",")
+			if _, ok := links[style]; !ok {
+				linkStyles = append(linkStyles, style)
+			}
+			links[style] = append(links[style], strconv.Itoa(i))
+		}
+	}
+	for _, style := range linkStyles {
+		_, _ = b.WriteString(fmt.Sprintf("  linkStyle %s %s;\n", strings.Join(links[style], ","), style))
+	}
+
+	return b.String()
+}
+
+// PlantUML renders a dependency graph as a PlantUML diagram.
+// Semantics match DOT; PlantUML has no labeled diamond element, so missing dependencies are dashed hexagons.
+func (g Graph) PlantUML() string {
+	var b strings.Builder
+	_, _ = b.WriteString("@startuml\n")
+	_, _ = b.WriteString("left to right direction\n")
+
+	ids := g.renderNodeIDs()
+	for _, node := range g.Providers {
+		label := escapePlantUML(strings.Join(providerLabelParts(node), "\\n"))
+		switch node.Kind {
+		case dependencyKindString(dependencyKindDecorate):
+			_, _ = b.WriteString(fmt.Sprintf("usecase \"%s\" as %s #line.dashed\n", label, ids[node.ID]))
+		case dependencyKindString(dependencyKindReplace):
+			_, _ = b.WriteString(fmt.Sprintf("rectangle \"%s\" as %s #line.bold\n", label, ids[node.ID]))
+		default:
+			_, _ = b.WriteString(fmt.Sprintf("rectangle \"%s\" as %s\n", label, ids[node.ID]))
+		}
+	}
+
+	for i, missing := range g.missingNodes() {
+		ids[missing.id] = fmt.Sprintf("m%d", i)
+		_, _ = b.WriteString(fmt.Sprintf("hexagon \"%s\" as %s #line.dashed\n", escapePlantUML(missing.label), ids[missing.id]))
+	}
+
+	cycleEdges := g.cycleEdges()
+	for _, edge := range g.Edges {
+		target := edge.To
+		if edge.Missing {
+			target = missingNodeID(edge)
+		}
+		styles := make([]string, 0, 2)
+		if !edge.Missing && cycleEdges[edge.From+"|"+edge.To] {
+			styles = append(styles, "#red")
+		}
+		if edge.Optional {
+			styles = append(styles, "dashed")
+		} else if edge.Lazy {
+			styles = append(styles, "dotted")
+		}
+		arrow := "-->"
+		if len(styles) > 0 {
+			arrow = "-[" + strings.Join(styles, ",") + "]->"
+		}
+		_, _ = b.WriteString(fmt.Sprintf(
+			"%s %s %s : %s\n",
+			ids[edge.From],
+			arrow,
+			ids[target],
+			escapePlantUML(buildEdgeLabel(edge)),
+		))
+	}
+
+	_, _ = b.WriteString("@enduml\n")
+	return b.String()
+}
+
+// renderNodeIDs maps provider IDs to short identifiers safe for Mermaid and PlantUML.
+func (g Graph) renderNodeIDs() map[string]string {
+	ids := make(map[string]string, len(g.Providers))
+	for i, node := range g.Providers {
+		if _, ok := ids[node.ID]; !ok {
+			ids[node.ID] = fmt.Sprintf("n%d", i)
+		}
+	}
+	return ids
+}
+
+func escapeMermaid(s string) string {
+	s = strings.ReplaceAll(s, "\"", "#quot;")
+	s = strings.ReplaceAll(s, "<br/>", "\x00")
+	s = strings.ReplaceAll(s, "<", "#lt;")
+	s = strings.ReplaceAll(s, ">", "#gt;")
+	return strings.ReplaceAll(s, "\x00", "<br/>")
+}
+
+func escapePlantUML(s string) string {
+	return strings.ReplaceAll(s, "\"", "'")
+}
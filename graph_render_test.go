@@ -0,0 +1,81 @@
+package godi_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/assurrussa/godi"
+)
+
+func renderTestGraph() godi.Graph {
+	return godi.BuildGraph(godi.CollectDependencies(
+		godi.NewDependency(func() string { return testBase }),
+		godi.Replace(func() string { return testOverride }),
+		godi.Decorate(func(s string) string { return s + "!" }),
+		godi.NewDependency(func(_ string, _ godi.Optional[int], _ float64) bool { return true }),
+	))
+}
+
+func TestGraphMermaid(t *testing.T) {
+	t.Parallel()
+
+	out := renderTestGraph().Mermaid()
+	for _, want := range []string{
+		"flowchart LR",
+		`(["`,         // decorator shape
+		":::decorate", // dashed decorator
+		":::replace",  // bold replacement
+		`{"float64"}`, // missing diamond
+		"-.->",        // optional edge
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected Mermaid output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestGraphMermaidLazyEdges(t *testing.T) {
+	t.Parallel()
+
+	out := godi.BuildGraph(godi.CollectDependencies(
+		godi.NewDependency(func() int { return 1 }),
+		godi.NewDependency(func(godi.Lazy[int], godi.Optional[int]) string { return testBase }),
+	)).Mermaid()
+	if !strings.Contains(out, "linkStyle 0 stroke-dasharray:2 2;") {
+		t.Fatalf("expected the lazy edge to get its own dash pattern, got:\n%s", out)
+	}
+	if strings.Count(out, "linkStyle") != 1 {
+		t.Fatalf("expected the optional edge to keep the plain dotted arrow, got:\n%s", out)
+	}
+}
+
+func TestGraphPlantUML(t *testing.T) {
+	t.Parallel()
+
+	out := renderTestGraph().PlantUML()
+	for _, want := range []string{
+		"@startuml",
+		"usecase ",
+		"#line.dashed",
+		"#line.bold",
+		`hexagon "float64"`,
+		"-[dashed]->",
+		"@enduml",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected PlantUML output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestGraphRenderersHighlightCycles(t *testing.T) {
+	t.Parallel()
+
+	g := godi.BuildGraph(cycleDeps())
+	if !strings.Contains(g.Mermaid(), "stroke:red") {
+		t.Fatalf("expected Mermaid to color cycle edges, got:\n%s", g.Mermaid())
+	}
+	if !strings.Contains(g.PlantUML(), "-[#red]->") {
+		t.Fatalf("expected PlantUML to color cycle edges, got:\n%s", g.PlantUML())
+	}
+}
@@ -1,7 +1,6 @@
 package godi_test
 
 import (
-	"strconv"
 	"strings"
 	"testing"
 
@@ -13,18 +12,6 @@ const (
 	kindReplace  = "replace"
 )
 
-func idxFromProviderID(id string) (int, bool) {
-	i := strings.LastIndex(id, "#")
-	if i < 0 || i == len(id)-1 {
-		return 0, false
-	}
-	n, err := strconv.Atoi(id[i+1:])
-	if err != nil {
-		return 0, false
-	}
-	return n, true
-}
-
 func TestBuildGraphDecoratorChainEdges(t *testing.T) {
 	t.Parallel()
 
@@ -36,13 +23,10 @@ func TestBuildGraphDecoratorChainEdges(t *testing.T) {
 
 	g := godi.BuildGraph(deps)
 
+	// Providers come first, then decorators, each in declaration order.
 	nodesByIdx := map[int]godi.ProviderNode{}
-	for _, n := range g.Providers {
-		idx, ok := idxFromProviderID(n.ID)
-		if !ok {
-			continue
-		}
-		nodesByIdx[idx] = n
+	for i, n := range g.Providers {
+		nodesByIdx[i] = n
 	}
 
 	base, ok := nodesByIdx[0]
@@ -0,0 +1,130 @@
+package godi
+
+import (
+	"errors"
+	"time"
+
+	"go.uber.org/dig"
+)
+
+// ConstructorEvent describes one constructor or decorator call made by the container.
+type ConstructorEvent struct {
+	// ID identifies the call's graph node, qualified with the module for module providers
+	// (for example "storage/ctor:app.NewDB").
+	ID string
+	// Dependencies lists the IDs of the providers and decorators whose values are passed to the call,
+	// as derived from graph edges. They finish before the call starts, so a tracer can make their spans
+	// children of this call's span.
+	Dependencies []string
+	Constructor  string
+	Module       string
+	Kind         string
+	Type         string
+	Name         string
+	Group        string
+	Start        time.Time
+	// Duration, Error and Panic are set on finished events. Panic holds the recovered value when the call
+	// panicked; Error is then the dig.PanicError wrapping it.
+	Duration time.Duration
+	Error    error
+	Panic    any
+}
+
+// Observer receives constructor and decorator events; see WithInstrumentation.
+// Calls happen synchronously on the goroutine that resolves the dependency.
+type Observer interface {
+	ConstructorStarted(event ConstructorEvent)
+	ConstructorFinished(event ConstructorEvent)
+}
+
+// ObserverFunc is an Observer that only receives finished events.
+type ObserverFunc func(event ConstructorEvent)
+
+func (f ObserverFunc) ConstructorStarted(ConstructorEvent) {}
+
+func (f ObserverFunc) ConstructorFinished(event ConstructorEvent) { f(event) }
+
+// eventDependencies maps qualified node IDs to the qualified IDs of their edge targets. Every node is taken
+// from the graph of the scope it resolves in: root providers from the root graph, module providers and
+// decorators from their module graph.
+func eventDependencies(graphs map[string]Graph) map[string][]string {
+	deps := map[string][]string{}
+	for scope, graph := range graphs {
+		nodes := make(map[string]ProviderNode, len(graph.Providers))
+		for _, node := range graph.Providers {
+			nodes[node.ID] = node
+		}
+		for _, edge := range graph.Edges {
+			from, ok := nodes[edge.From]
+			if !ok || edge.Missing || !resolvesIn(from, scope) {
+				continue
+			}
+			if to, ok := nodes[edge.To]; ok {
+				id := qualifiedNodeID(from)
+				deps[id] = appendUniqueString(deps[id], qualifiedNodeID(to))
+			}
+		}
+	}
+	return deps
+}
+
+func resolvesIn(node ProviderNode, scope string) bool {
+	if node.Module == "" {
+		return scope == rootScopeName
+	}
+	return node.Module == scope
+}
+
+func appendUniqueString(list []string, item string) []string {
+	for _, existing := range list {
+		if existing == item {
+			return list
+		}
+	}
+	return append(list, item)
+}
+
+func (r *timingRecorder) event(entry depEntry) ConstructorEvent {
+	info := describeEntry(entry)
+	id := entry.id
+	if entry.module != "" {
+		id = entry.module + "/" + id
+	}
+	return ConstructorEvent{
+		ID:           id,
+		Dependencies: r.dependencies[id],
+		Constructor:  info.Constructor,
+		Module:       entry.module,
+		Kind:         dependencyKindString(entry.dep.kind),
+		Type:         info.Type,
+		Name:         info.Name,
+		Group:        info.Group,
+	}
+}
+
+func (r *timingRecorder) beforeCallback(entry depEntry) dig.BeforeCallback {
+	event := r.event(entry)
+	return func(dig.BeforeCallbackInfo) {
+		started := event
+		started.Start = time.Now()
+		for _, observer := range r.observers {
+			observer.ConstructorStarted(started)
+		}
+	}
+}
+
+func (r *timingRecorder) observe(event ConstructorEvent, call dig.CallbackInfo) {
+	if len(r.observers) == 0 {
+		return
+	}
+	event.Start = time.Now().Add(-call.Runtime)
+	event.Duration = call.Runtime
+	event.Error = call.Error
+	var panicErr dig.PanicError
+	if errors.As(call.Error, &panicErr) {
+		event.Panic = panicErr.Panic
+	}
+	for _, observer := range r.observers {
+		observer.ConstructorFinished(event)
+	}
+}
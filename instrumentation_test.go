@@ -0,0 +1,138 @@
+package godi_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/assurrussa/godi"
+)
+
+type recordingObserver struct {
+	mu       sync.Mutex
+	started  []godi.ConstructorEvent
+	finished []godi.ConstructorEvent
+}
+
+func (o *recordingObserver) ConstructorStarted(event godi.ConstructorEvent) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.started = append(o.started, event)
+}
+
+func (o *recordingObserver) ConstructorFinished(event godi.ConstructorEvent) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.finished = append(o.finished, event)
+}
+
+func (o *recordingObserver) byType(typ string) godi.ConstructorEvent {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, event := range o.finished {
+		if event.Type == typ && event.Kind != "decorate" {
+			return event
+		}
+	}
+	return godi.ConstructorEvent{}
+}
+
+type (
+	instrumentedConfig struct{}
+	instrumentedDB     struct{}
+	instrumentedServer struct{}
+)
+
+func TestWithInstrumentationReportsCalls(t *testing.T) {
+	t.Parallel()
+
+	observer := &recordingObserver{}
+	var finishedTypes []string
+	cnt, err := godi.NewContainer(
+		godi.WithInstrumentation(observer, godi.ObserverFunc(func(event godi.ConstructorEvent) {
+			finishedTypes = append(finishedTypes, event.Type)
+		})),
+		godi.WithModules(godi.NewModule("storage", godi.CollectDependencies(
+			godi.NewDependency(func() instrumentedConfig { return instrumentedConfig{} }, godi.Private()),
+			godi.NewDependency(func(instrumentedConfig) *instrumentedDB {
+				time.Sleep(5 * time.Millisecond)
+				return &instrumentedDB{}
+			}),
+		))),
+		godi.WithDependencies(godi.CollectDependencies(
+			godi.NewDependency(func(*instrumentedDB) *instrumentedServer { return &instrumentedServer{} }),
+			godi.Decorate(func(s *instrumentedServer) *instrumentedServer { return s }),
+		)),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cnt.Validate(); err != nil {
+		t.Fatalf("unexpected validate error: %v", err)
+	}
+	if len(observer.finished) != 0 {
+		t.Fatalf("validation must not emit events, got %d", len(observer.finished))
+	}
+
+	if err := cnt.Invoke(func(*instrumentedServer) {}); err != nil {
+		t.Fatalf("unexpected invoke error: %v", err)
+	}
+
+	if len(observer.started) != 4 || len(observer.finished) != 4 || len(finishedTypes) != 4 {
+		t.Fatalf("expected 4 started and finished events, got %d/%d/%d",
+			len(observer.started), len(observer.finished), len(finishedTypes))
+	}
+
+	db := observer.byType("*godi_test.instrumentedDB")
+	if db.Module != "storage" || db.Duration < 5*time.Millisecond || db.Start.IsZero() || db.Error != nil {
+		t.Fatalf("unexpected db event: %+v", db)
+	}
+	config := observer.byType("godi_test.instrumentedConfig")
+	if len(db.Dependencies) != 1 || db.Dependencies[0] != config.ID {
+		t.Fatalf("expected db to depend on %q, got %v", config.ID, db.Dependencies)
+	}
+	server := observer.byType("*godi_test.instrumentedServer")
+	if len(server.Dependencies) != 1 || server.Dependencies[0] != db.ID {
+		t.Fatalf("expected server to depend on %q, got %v", db.ID, server.Dependencies)
+	}
+
+	last := observer.finished[len(observer.finished)-1]
+	if last.Kind != "decorate" || len(last.Dependencies) != 1 || last.Dependencies[0] != server.ID {
+		t.Fatalf("unexpected decorator event: %+v", last)
+	}
+}
+
+func TestWithInstrumentationReportsErrorsAndPanics(t *testing.T) {
+	t.Parallel()
+
+	observer := &recordingObserver{}
+	errBoom := errors.New("boom")
+	cnt, err := godi.NewContainer(
+		godi.WithInstrumentation(observer),
+		godi.WithDependencies(godi.CollectDependencies(
+			godi.NewDependency(func() (int, error) { return 0, errBoom }),
+			godi.NewDependency(func() string { panic("kaboom") }),
+		)),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := cnt.Invoke(func(int) {}); err == nil {
+		t.Fatal("expected invoke error")
+	}
+	if err := cnt.Invoke(func(string) {}); err == nil {
+		t.Fatal("expected invoke panic error")
+	}
+
+	if len(observer.finished) != 2 {
+		t.Fatalf("expected 2 finished events, got %d", len(observer.finished))
+	}
+	if failed := observer.finished[0]; !errors.Is(failed.Error, errBoom) || failed.Panic != nil {
+		t.Fatalf("unexpected error event: %+v", failed)
+	}
+	if panicked := observer.finished[1]; panicked.Error == nil || panicked.Panic != "kaboom" {
+		t.Fatalf("unexpected panic event: %+v", panicked)
+	}
+}
@@ -0,0 +1,205 @@
+package godi
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"go.uber.org/dig"
+)
+
+// Lazy defers construction of a dependency until the first Get call.
+// Resolution happens once and is safe for concurrent use: Get calls of all Lazy values of a container
+// resolve one at a time, since dig is not safe for concurrent use. Lazy edges do not participate in cycle
+// detection, so Lazy can break construction cycles. Get must not be called from a constructor, including
+// the one that receives the Lazy value.
+type Lazy[T any] struct {
+	state *lazyState
+}
+
+// Get resolves the underlying dependency on first call and returns the cached result afterwards.
+func (l Lazy[T]) Get() (T, error) {
+	var zero T
+	if l.state == nil {
+		return zero, errors.New("lazy dependency is not bound to a container")
+	}
+
+	v, err := l.state.get()
+	if err != nil {
+		return zero, err
+	}
+
+	if v == nil {
+		return zero, nil
+	}
+	result, ok := v.(T)
+	if !ok {
+		return zero, errors.New("lazy dependency resolved to an unexpected type")
+	}
+	return result, nil
+}
+
+func (l *Lazy[T]) bindLazy(state *lazyState) {
+	l.state = state
+}
+
+func (l *Lazy[T]) lazyElem() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+type lazyBinder interface {
+	bindLazy(state *lazyState)
+	lazyElem() reflect.Type
+}
+
+type lazyState struct {
+	once sync.Once
+	// mu is shared by every lazyState of a container and serialises resolution through dig.
+	mu      *sync.Mutex
+	resolve func() (any, error)
+	value   any
+	err     error
+}
+
+func (s *lazyState) get() (any, error) {
+	s.once.Do(func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.value, s.err = s.resolve()
+	})
+	return s.value, s.err
+}
+
+// lazyElemType reports the wrapped type when t is a Lazy[T] instantiation.
+func lazyElemType(t reflect.Type) (reflect.Type, bool) {
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, false
+	}
+	ptr := reflect.PointerTo(t)
+	if !ptr.Implements(reflect.TypeFor[lazyBinder]()) {
+		return nil, false
+	}
+	binder, ok := reflect.New(t).Interface().(lazyBinder)
+	if !ok {
+		return nil, false
+	}
+	return binder.lazyElem(), true
+}
+
+// lazySlot identifies a Lazy[T] parameter: slot is the wrapped dependency, lazyType is Lazy[T] itself.
+type lazySlot struct {
+	slot     slotKey
+	lazyType reflect.Type
+}
+
+func collectLazySlots(entries ...[]depEntry) ([]lazySlot, error) {
+	var tokens []GraphToken
+	for _, list := range entries {
+		for _, entry := range list {
+			tokens = append(tokens, buildRequireTokens(entry.dep)...)
+		}
+	}
+	return lazySlotsOf(tokens, map[slotKey]bool{})
+}
+
+// lazySlotsOf returns the Lazy[T] parameters among tokens whose Lazy slot is not in seen yet and adds them
+// to seen. Lazy parameters cannot be optional: the Lazy value always exists and Get reports a missing slot.
+func lazySlotsOf(tokens []GraphToken, seen map[slotKey]bool) ([]lazySlot, error) {
+	result := make([]lazySlot, 0)
+	for _, token := range tokens {
+		if !token.Lazy || token.lazyType == nil {
+			continue
+		}
+		slot := slotKey{t: token.typ, name: token.Name}
+		if token.Optional {
+			return nil, fmt.Errorf(
+				"lazy %s: optional:\"true\" is not supported on %s, Get reports a missing dependency instead",
+				slotLabel(slot), token.lazyType,
+			)
+		}
+		key := slotKey{t: token.lazyType, name: token.Name}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, lazySlot{slot: slot, lazyType: token.lazyType})
+	}
+	return result, nil
+}
+
+func provideLazySlots(scope interface {
+	Provide(constructor any, opts ...dig.ProvideOption) error
+	Invoke(function any, opts ...dig.InvokeOption) error
+}, slots []lazySlot, mu *sync.Mutex,
+) error {
+	for _, lazy := range slots {
+		options := make([]dig.ProvideOption, 0, 1)
+		if lazy.slot.name != "" {
+			options = append(options, dig.Name(lazy.slot.name))
+		}
+		if err := scope.Provide(buildLazyConstructor(scope, lazy, mu), options...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func buildLazyConstructor(scope interface {
+	Invoke(function any, opts ...dig.InvokeOption) error
+}, lazy lazySlot, mu *sync.Mutex,
+) any {
+	fnType := reflect.FuncOf(nil, []reflect.Type{lazy.lazyType}, false)
+	fn := reflect.MakeFunc(fnType, func([]reflect.Value) []reflect.Value {
+		ptr := reflect.New(lazy.lazyType)
+		if binder, ok := ptr.Interface().(lazyBinder); ok {
+			binder.bindLazy(&lazyState{mu: mu, resolve: func() (any, error) {
+				return resolveSlot(scope, lazy.slot)
+			}})
+		}
+		return []reflect.Value{ptr.Elem()}
+	})
+	return fn.Interface()
+}
+
+// resolveSlot invokes the scope with a function capturing the value of a single slot.
+func resolveSlot(scope interface {
+	Invoke(function any, opts ...dig.InvokeOption) error
+}, slot slotKey,
+) (any, error) {
+	var captured reflect.Value
+	var in reflect.Type
+	if slot.name != "" {
+		in = reflect.StructOf([]reflect.StructField{
+			{
+				Name:      "In",
+				Type:      reflect.TypeOf(dig.In{}),
+				Anonymous: true,
+			},
+			{
+				Name: "Item",
+				Type: slot.t,
+				Tag:  reflect.StructTag(`name:"` + slot.name + `"`),
+			},
+		})
+	} else {
+		in = slot.t
+	}
+
+	fnType := reflect.FuncOf([]reflect.Type{in}, nil, false)
+	fn := reflect.MakeFunc(fnType, func(args []reflect.Value) []reflect.Value {
+		captured = args[0]
+		if slot.name != "" {
+			captured = captured.Field(1)
+		}
+		return nil
+	})
+
+	if err := scope.Invoke(fn.Interface()); err != nil {
+		return nil, err
+	}
+	if !captured.IsValid() {
+		return nil, errors.New("lazy dependency was not resolved")
+	}
+	return captured.Interface(), nil
+}
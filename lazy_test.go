@@ -0,0 +1,251 @@
+package godi_test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"go.uber.org/dig"
+
+	"github.com/assurrussa/godi"
+)
+
+type (
+	lazyClient  struct{ name string }
+	lazyService struct{ client godi.Lazy[*lazyClient] }
+	lazyPing    struct{ pong godi.Lazy[*lazyPong] }
+	lazyPong    struct{ ping *lazyPing }
+)
+
+func TestLazyDefersConstruction(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	calls := 0
+	cnt, err := godi.NewContainer(godi.WithDependencies(godi.CollectDependencies(
+		godi.NewDependency(func() *lazyClient {
+			mu.Lock()
+			defer mu.Unlock()
+			calls++
+			return &lazyClient{name: "client"}
+		}),
+		godi.NewDependency(func(c godi.Lazy[*lazyClient]) *lazyService { return &lazyService{client: c} }),
+	)))
+	if err != nil {
+		t.Fatalf("NewContainer error: %v", err)
+	}
+	if err := cnt.Validate(); err != nil {
+		t.Fatalf("Validate error: %v", err)
+	}
+
+	var svc *lazyService
+	if err := cnt.Invoke(func(s *lazyService) { svc = s }); err != nil {
+		t.Fatalf("Invoke error: %v", err)
+	}
+	if calls != 0 {
+		t.Fatalf("expected client not to be constructed yet, got %d calls", calls)
+	}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c, err := svc.client.Get()
+			if err != nil || c.name != "client" {
+				t.Errorf("unexpected lazy result: %v, %v", c, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if calls != 1 {
+		t.Fatalf("expected client to be constructed once, got %d", calls)
+	}
+}
+
+type lazyHolder struct {
+	client godi.Lazy[*lazyClient]
+	pong   godi.Lazy[*lazyPong]
+}
+
+func TestLazyResolvesDistinctSlotsConcurrently(t *testing.T) {
+	t.Parallel()
+
+	cnt, err := godi.NewContainer(godi.WithDependencies(godi.CollectDependencies(
+		godi.NewDependency(func() *lazyClient { return &lazyClient{name: "client"} }),
+		godi.NewDependency(func() *lazyPing { return &lazyPing{} }),
+		godi.NewDependency(func(p *lazyPing) *lazyPong { return &lazyPong{ping: p} }),
+		godi.NewDependency(func(c godi.Lazy[*lazyClient], p godi.Lazy[*lazyPong]) *lazyHolder {
+			return &lazyHolder{client: c, pong: p}
+		}),
+	)))
+	if err != nil {
+		t.Fatalf("NewContainer error: %v", err)
+	}
+
+	var holder *lazyHolder
+	if err := cnt.Invoke(func(h *lazyHolder) { holder = h }); err != nil {
+		t.Fatalf("Invoke error: %v", err)
+	}
+
+	// Each Lazy has its own Once; resolution must still not run dig from two goroutines at a time.
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if c, err := holder.client.Get(); err != nil || c.name != "client" {
+			t.Errorf("unexpected client: %v, %v", c, err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		if p, err := holder.pong.Get(); err != nil || p.ping == nil {
+			t.Errorf("unexpected pong: %v, %v", p, err)
+		}
+	}()
+	wg.Wait()
+}
+
+func TestLazyBreaksConstructionCycle(t *testing.T) {
+	t.Parallel()
+
+	deps := godi.CollectDependencies(
+		godi.NewDependency(func(p godi.Lazy[*lazyPong]) *lazyPing { return &lazyPing{pong: p} }),
+		godi.NewDependency(func(p *lazyPing) *lazyPong { return &lazyPong{ping: p} }),
+	)
+
+	cnt, err := godi.NewContainer(godi.WithDependencies(deps))
+	if err != nil {
+		t.Fatalf("NewContainer error: %v", err)
+	}
+
+	var ping *lazyPing
+	if err := cnt.Invoke(func(p *lazyPing) { ping = p }); err != nil {
+		t.Fatalf("Invoke error: %v", err)
+	}
+	pong, err := ping.pong.Get()
+	if err != nil {
+		t.Fatalf("Get error: %v", err)
+	}
+	if pong.ping != ping {
+		t.Fatal("expected pong to reference the same ping instance")
+	}
+
+	g := godi.BuildGraph(deps)
+	var lazyEdges int
+	for _, e := range g.Edges {
+		if e.Lazy {
+			lazyEdges++
+		}
+	}
+	if lazyEdges != 1 {
+		t.Fatalf("expected 1 lazy edge, got %d", lazyEdges)
+	}
+	if len(g.Cycles()) != 0 {
+		t.Fatalf("expected lazy edge to break cycle, got %+v", g.Cycles())
+	}
+	if !strings.Contains(g.DOT(), "style=dotted") {
+		t.Fatalf("expected lazy edge to be dotted in DOT, got:\n%s", g.DOT())
+	}
+}
+
+func TestLazyNamedDependencyAndMissingTarget(t *testing.T) {
+	t.Parallel()
+
+	cnt, err := godi.NewContainer(godi.WithDependencies(godi.CollectDependencies(
+		godi.NewDependency(func() string { return testBase }, godi.WithName(testNameN)),
+		godi.NewDependency(func(in struct {
+			dig.In
+			S godi.Lazy[string] `name:"n"`
+		},
+		) int {
+			s, _ := in.S.Get()
+			return len(s)
+		}),
+	)))
+	if err != nil {
+		t.Fatalf("NewContainer error: %v", err)
+	}
+
+	var got int
+	if err := cnt.Invoke(func(v int) { got = v }); err != nil {
+		t.Fatalf("Invoke error: %v", err)
+	}
+	if got != len(testBase) {
+		t.Fatalf("expected %d, got %d", len(testBase), got)
+	}
+
+	missing, err := godi.NewContainer(godi.WithDependencies(
+		godi.NewSingleDependency(func(godi.Lazy[*lazyClient]) int { return 1 }),
+	))
+	if err != nil {
+		t.Fatalf("NewContainer error: %v", err)
+	}
+	if err := missing.Validate(); err == nil {
+		t.Fatal("expected validation error for missing lazy target")
+	}
+}
+
+func TestLazyZeroValueGetFails(t *testing.T) {
+	t.Parallel()
+
+	var l godi.Lazy[string]
+	if _, err := l.Get(); err == nil {
+		t.Fatal("expected error for unbound lazy")
+	}
+}
+
+func TestLazyInvokeConsumer(t *testing.T) {
+	t.Parallel()
+
+	built := 0
+	cnt, err := godi.NewContainer(godi.WithDependencies(godi.CollectDependencies(
+		godi.NewDependency(func() *lazyClient {
+			built++
+			return &lazyClient{name: testBase}
+		}),
+	)))
+	if err != nil {
+		t.Fatalf("NewContainer error: %v", err)
+	}
+
+	// The second Invoke reuses the Lazy provider registered by the first one.
+	for range 2 {
+		var client godi.Lazy[*lazyClient]
+		if err := cnt.Invoke(func(l godi.Lazy[*lazyClient]) { client = l }); err != nil {
+			t.Fatalf("Invoke error: %v", err)
+		}
+		got, err := client.Get()
+		if err != nil || got.name != testBase {
+			t.Fatalf("expected %q, got %+v, %v", testBase, got, err)
+		}
+	}
+	if built != 1 {
+		t.Fatalf("expected client to be built once, built %d times", built)
+	}
+}
+
+func TestLazyRejectsOptionalTag(t *testing.T) {
+	t.Parallel()
+
+	type optionalLazy struct {
+		dig.In
+		Client godi.Lazy[*lazyClient] `optional:"true"`
+	}
+
+	_, err := godi.NewContainer(godi.WithDependencies(
+		godi.NewSingleDependency(func(optionalLazy) int { return 1 }),
+	))
+	if err == nil || !strings.Contains(err.Error(), `optional:"true" is not supported`) {
+		t.Fatalf("expected optional lazy error from NewContainer, got %v", err)
+	}
+
+	cnt, err := godi.NewContainer()
+	if err != nil {
+		t.Fatalf("NewContainer error: %v", err)
+	}
+	err = cnt.Invoke(func(optionalLazy) {})
+	if err == nil || !strings.Contains(err.Error(), `optional:"true" is not supported`) {
+		t.Fatalf("expected optional lazy error from Invoke, got %v", err)
+	}
+}
@@ -3,7 +3,17 @@ package godi
 import (
 	"context"
 	"errors"
+	"log/slog"
 	"sync"
+	"time"
+)
+
+// Hook states reported by Lifecycle.States.
+const (
+	HookStatePending = "pending"
+	HookStateStarted = "started"
+	HookStateStopped = "stopped"
+	HookStateFailed  = "failed"
 )
 
 type Hook struct {
@@ -11,10 +21,22 @@ type Hook struct {
 	OnStop  func(context.Context) error
 }
 
+// HookState describes a registered hook and the outcome of its last start/stop call.
+type HookState struct {
+	Index int    `json:"index"`
+	State string `json:"state"`
+	Error string `json:"error,omitempty"`
+}
+
 // Lifecycle manages start/stop hooks in order (start) and reverse order (stop).
 type Lifecycle struct {
-	mu    sync.Mutex
-	hooks []Hook
+	mu     sync.Mutex
+	hooks  []Hook
+	states []HookState
+	// logger and tracer are set for the default lifecycle of a container configured with WithLogger
+	// and WithStartupTrace.
+	logger *slog.Logger
+	tracer *startupTracer
 }
 
 func NewLifecycle() *Lifecycle {
@@ -24,6 +46,7 @@ func NewLifecycle() *Lifecycle {
 func (l *Lifecycle) Append(h Hook) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
+	l.states = append(l.states, HookState{Index: len(l.hooks), State: HookStatePending})
 	l.hooks = append(l.hooks, h)
 }
 
@@ -31,12 +54,18 @@ func (l *Lifecycle) Start(ctx context.Context) error {
 	hooks := l.snapshot()
 	for i, hook := range hooks {
 		if hook.OnStart == nil {
+			l.setState(i, HookStateStarted, nil)
 			continue
 		}
+		started := time.Now()
 		if err := hook.OnStart(ctx); err != nil {
+			l.observe("start", i, started, err)
+			l.setState(i, HookStateFailed, err)
 			_ = l.stopStarted(ctx, hooks[:i])
 			return err
 		}
+		l.observe("start", i, started, nil)
+		l.setState(i, HookStateStarted, nil)
 	}
 	return nil
 }
@@ -46,22 +75,51 @@ func (l *Lifecycle) Stop(ctx context.Context) error {
 	return l.stopStarted(ctx, hooks)
 }
 
+// States returns a snapshot of hook states in registration order. It is safe for concurrent use.
+func (l *Lifecycle) States() []HookState {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]HookState(nil), l.states...)
+}
+
 func (l *Lifecycle) snapshot() []Hook {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	return append([]Hook(nil), l.hooks...)
 }
 
+func (l *Lifecycle) setState(i int, state string, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.states[i].State = state
+	l.states[i].Error = ""
+	if err != nil {
+		l.states[i].Error = err.Error()
+	}
+}
+
+func (l *Lifecycle) observe(action string, index int, started time.Time, err error) {
+	logHook(l.logger, action, index, started, err)
+	l.tracer.hook(action, index, started, err)
+}
+
 func (l *Lifecycle) stopStarted(ctx context.Context, hooks []Hook) error {
 	var stopErr error
 	for i := len(hooks) - 1; i >= 0; i-- {
 		hook := hooks[i]
 		if hook.OnStop == nil {
+			l.setState(i, HookStateStopped, nil)
 			continue
 		}
-		if err := hook.OnStop(ctx); err != nil {
+		started := time.Now()
+		err := hook.OnStop(ctx)
+		l.observe("stop", i, started, err)
+		if err != nil {
+			l.setState(i, HookStateFailed, err)
 			stopErr = errors.Join(stopErr, err)
+			continue
 		}
+		l.setState(i, HookStateStopped, nil)
 	}
 	return stopErr
 }
@@ -0,0 +1,180 @@
+package godi
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Attribute keys used by WithLogger.
+const (
+	LogKeySlot        = "godi.slot"
+	LogKeyModule      = "godi.module"
+	LogKeyConstructor = "godi.constructor"
+	LogKeyKind        = "godi.kind"
+	LogKeyReplaced    = "godi.replaced"
+	LogKeyDuration    = "godi.duration"
+	LogKeyHook        = "godi.hook"
+	LogKeyError       = "godi.error"
+)
+
+// loggingObserver logs constructor and decorator calls.
+type loggingObserver struct {
+	logger *slog.Logger
+}
+
+func (o loggingObserver) ConstructorStarted(ConstructorEvent) {}
+
+func (o loggingObserver) ConstructorFinished(event ConstructorEvent) {
+	attrs := []slog.Attr{
+		slog.String(LogKeySlot, eventSlot(event)),
+		slog.String(LogKeyModule, event.Module),
+		slog.String(LogKeyConstructor, event.Constructor),
+		slog.String(LogKeyKind, event.Kind),
+		slog.Duration(LogKeyDuration, event.Duration),
+	}
+	if event.Error != nil {
+		o.logger.LogAttrs(context.Background(), slog.LevelError, "godi: constructor failed",
+			append(attrs, slog.String(LogKeyError, event.Error.Error()))...)
+		return
+	}
+	o.logger.LogAttrs(context.Background(), slog.LevelDebug, "godi: constructor called", attrs...)
+}
+
+func eventSlot(event ConstructorEvent) string {
+	return slotString(event.Type, event.Name, event.Group)
+}
+
+func slotString(typ, name, group string) string {
+	switch {
+	case group != "":
+		return typ + "[group=" + group + "]"
+	case name != "":
+		return typ + "[name=" + name + "]"
+	default:
+		return typ
+	}
+}
+
+func (c *Container) log(level slog.Level, msg string, attrs ...slog.Attr) {
+	if c.logger == nil {
+		return
+	}
+	c.logger.LogAttrs(context.Background(), level, msg, attrs...)
+}
+
+// logBuild logs the providers, decorators and overrides of a successful build. Provide rebuilds the whole
+// graph, so root entries below from and module entries (fixed at construction) were logged by an earlier build.
+func (c *Container) logBuild(built *buildResult, from int) {
+	if c.logger == nil {
+		return
+	}
+
+	for _, provider := range built.rootProviders {
+		if provider.idx >= from {
+			c.logEntry(slog.LevelDebug, "godi: provide", provider)
+		}
+	}
+	for _, decorator := range built.globalResolution.decorators {
+		if decorator.idx >= from {
+			c.logEntry(slog.LevelDebug, "godi: decorate", decorator)
+		}
+	}
+
+	if from == 0 {
+		for _, module := range c.modules {
+			provided := map[int]bool{}
+			for _, provider := range built.moduleProviders[module.Name] {
+				provided[provider.idx] = true
+				c.logEntry(slog.LevelDebug, "godi: provide", provider)
+			}
+			res := built.moduleResolutions[module.Name]
+			for _, provider := range res.providers {
+				if !provided[provider.idx] {
+					c.logEntry(slog.LevelDebug, "godi: module provider lost root slot", provider)
+				}
+			}
+			for _, decorator := range res.decorators {
+				c.logEntry(slog.LevelDebug, "godi: decorate", decorator)
+			}
+		}
+	}
+
+	for _, override := range c.Overrides() {
+		if override.Kind == OverrideKindDecorate {
+			continue
+		}
+		if override.Scope == rootScopeName && override.Next.Module == "" && override.Next.Index < from {
+			continue
+		}
+		if override.Scope != rootScopeName && from > 0 {
+			continue
+		}
+		c.log(slog.LevelInfo, "godi: "+override.Kind,
+			slog.String(LogKeySlot, override.Key),
+			slog.String(LogKeyModule, override.Next.Module),
+			slog.String(LogKeyConstructor, override.Next.Constructor),
+			slog.String(LogKeyReplaced, override.Previous.Constructor),
+		)
+	}
+}
+
+func (c *Container) logEntry(level slog.Level, msg string, entry depEntry) {
+	info := describeEntry(entry)
+	slotsOf := dependencySlots
+	if entry.dep.kind == dependencyKindDecorate {
+		slotsOf = decoratorSlots
+	}
+	keys, _ := slotsOf(entry.dep)
+	slots := make([]string, 0, len(keys))
+	for _, key := range keys {
+		slots = append(slots, slotLabel(key))
+	}
+	c.log(level, msg,
+		slog.String(LogKeySlot, strings.Join(slots, ", ")),
+		slog.String(LogKeyModule, entry.module),
+		slog.String(LogKeyConstructor, info.Constructor),
+		slog.String(LogKeyKind, dependencyKindString(entry.dep.kind)),
+	)
+}
+
+func (c *Container) logInvoke(consumer any, started time.Time, err error) {
+	attrs := []slog.Attr{
+		slog.String(LogKeyConstructor, funcName(consumer)),
+		slog.Duration(LogKeyDuration, time.Since(started)),
+	}
+	if err != nil {
+		c.log(slog.LevelError, "godi: invoke failed", append(attrs, slog.String(LogKeyError, err.Error()))...)
+		return
+	}
+	c.log(slog.LevelDebug, "godi: invoke", attrs...)
+}
+
+func funcName(fn any) string {
+	val := reflect.ValueOf(fn)
+	if val.Kind() != reflect.Func || val.Pointer() == 0 {
+		return ""
+	}
+	if f := runtime.FuncForPC(val.Pointer()); f != nil {
+		return f.Name()
+	}
+	return ""
+}
+
+// logHook logs a default lifecycle hook call; action is "start" or "stop" and index is the hook position
+// in registration order.
+func logHook(logger *slog.Logger, action string, index int, started time.Time, err error) {
+	if logger == nil {
+		return
+	}
+	attrs := []slog.Attr{slog.Int(LogKeyHook, index), slog.Duration(LogKeyDuration, time.Since(started))}
+	if err != nil {
+		attrs = append(attrs, slog.String(LogKeyError, err.Error()))
+		logger.LogAttrs(context.Background(), slog.LevelError, "godi: hook "+action+" failed", attrs...)
+		return
+	}
+	logger.LogAttrs(context.Background(), slog.LevelInfo, "godi: hook "+action, attrs...)
+}
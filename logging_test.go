@@ -0,0 +1,237 @@
+package godi_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/assurrussa/godi"
+)
+
+// logRecords collects JSON log lines written by slog.
+type logRecords struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (r *logRecords) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.buf.Write(p)
+}
+
+func (r *logRecords) logger() *slog.Logger {
+	return slog.New(slog.NewJSONHandler(r, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+func (r *logRecords) records(t *testing.T) []map[string]any {
+	t.Helper()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(r.buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		record := map[string]any{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func (r *logRecords) find(t *testing.T, msg, key, value string) map[string]any {
+	t.Helper()
+	for _, record := range r.records(t) {
+		if record["msg"] == msg && (key == "" || record[key] == value) {
+			return record
+		}
+	}
+	t.Fatalf("no %q record with %s=%q in:\n%s", msg, key, value, r.buf.String())
+	return nil
+}
+
+type (
+	loggedDB     struct{}
+	loggedServer struct{}
+)
+
+func newLoggedDB() *loggedDB { return &loggedDB{} }
+
+func TestWithLoggerLogsRegistrationsAndCalls(t *testing.T) {
+	t.Parallel()
+
+	logs := &logRecords{}
+	cnt, err := godi.NewContainer(
+		godi.WithLogger(logs.logger()),
+		godi.WithModules(godi.NewModule("storage", godi.CollectDependencies(
+			godi.NewDependency(newLoggedDB),
+		))),
+		godi.WithDependencies(godi.CollectDependencies(
+			godi.NewDependency(func(*loggedDB) *loggedServer { return &loggedServer{} }),
+			godi.Replace(func(*loggedDB) *loggedServer { return &loggedServer{} }),
+			godi.Decorate(func(s *loggedServer) *loggedServer { return s }),
+		)),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	provide := logs.find(t, "godi: provide", godi.LogKeyModule, "storage")
+	if provide[godi.LogKeySlot] != "*godi_test.loggedDB" || provide["level"] != "DEBUG" {
+		t.Fatalf("unexpected provide record: %v", provide)
+	}
+	if constructor, _ := provide[godi.LogKeyConstructor].(string); !strings.HasSuffix(constructor, "newLoggedDB") {
+		t.Fatalf("unexpected constructor: %v", provide[godi.LogKeyConstructor])
+	}
+	replace := logs.find(t, "godi: replace", godi.LogKeySlot, "*godi_test.loggedServer")
+	if replace["level"] != "INFO" || replace[godi.LogKeyReplaced] == "" {
+		t.Fatalf("unexpected replace record: %v", replace)
+	}
+	logs.find(t, "godi: decorate", godi.LogKeySlot, "*godi_test.loggedServer")
+
+	if err := cnt.Invoke(func(*loggedServer) {}); err != nil {
+		t.Fatalf("unexpected invoke error: %v", err)
+	}
+	logs.find(t, "godi: invoke", "", "")
+	called := logs.find(t, "godi: constructor called", godi.LogKeySlot, "*godi_test.loggedDB")
+	if called[godi.LogKeyModule] != "storage" {
+		t.Fatalf("unexpected constructor record: %v", called)
+	}
+
+	if err := cnt.Invoke(func(string) {}); err == nil {
+		t.Fatalf("expected invoke error")
+	}
+	failed := logs.find(t, "godi: invoke failed", "", "")
+	if failed["level"] != "ERROR" || failed[godi.LogKeyError] == "" {
+		t.Fatalf("unexpected invoke failure record: %v", failed)
+	}
+}
+
+func TestWithLoggerLogsProvideOnce(t *testing.T) {
+	t.Parallel()
+
+	logs := &logRecords{}
+	cnt, err := godi.NewContainer(
+		godi.WithLogger(logs.logger()),
+		godi.WithDependencies(godi.CollectDependencies(godi.NewDependency(newLoggedDB))),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cnt.Provide(godi.CollectDependencies(
+		godi.NewDependency(func(*loggedDB) *loggedServer { return &loggedServer{} }),
+	)); err != nil {
+		t.Fatalf("unexpected provide error: %v", err)
+	}
+
+	count := 0
+	for _, record := range logs.records(t) {
+		if record["msg"] == "godi: provide" {
+			count++
+		}
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 provide records, got %d:\n%s", count, logs.buf.String())
+	}
+}
+
+func TestWithLoggerLogsValidationFailure(t *testing.T) {
+	t.Parallel()
+
+	logs := &logRecords{}
+	cnt, err := godi.NewContainer(
+		godi.WithLogger(logs.logger()),
+		godi.WithDependencies(godi.CollectDependencies(
+			godi.NewDependency(func(*loggedDB) *loggedServer { return &loggedServer{} }),
+		)),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cnt.Validate(); err == nil {
+		t.Fatalf("expected validation error")
+	}
+	record := logs.find(t, "godi: validation failed", "", "")
+	if message, _ := record[godi.LogKeyError].(string); record["level"] != "ERROR" || !strings.Contains(message, "loggedDB") {
+		t.Fatalf("unexpected validation record: %v", record)
+	}
+}
+
+func TestWithLoggerLogsBuildFailures(t *testing.T) {
+	t.Parallel()
+
+	logs := &logRecords{}
+	_, err := godi.NewContainer(
+		godi.WithLogger(logs.logger()),
+		godi.WithDependencies(godi.CollectDependencies(
+			godi.NewDependency(newLoggedDB),
+			godi.NewDependency(newLoggedDB),
+		)),
+	)
+	if err == nil {
+		t.Fatalf("expected a duplicate provider error")
+	}
+	record := logs.find(t, "godi: build failed", "", "")
+	if message, _ := record[godi.LogKeyError].(string); record["level"] != "ERROR" || message != err.Error() {
+		t.Fatalf("unexpected build record: %v", record)
+	}
+
+	provideLogs := &logRecords{}
+	cnt, err := godi.NewContainer(
+		godi.WithLogger(provideLogs.logger()),
+		godi.WithDependencies(godi.CollectDependencies(godi.NewDependency(newLoggedDB))),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = cnt.Provide(godi.CollectDependencies(godi.NewDependency(newLoggedDB)))
+	if err == nil {
+		t.Fatalf("expected a duplicate provider error")
+	}
+	record = provideLogs.find(t, "godi: build failed", "", "")
+	if message, _ := record[godi.LogKeyError].(string); record["level"] != "ERROR" || message != err.Error() {
+		t.Fatalf("unexpected provide record: %v", record)
+	}
+}
+
+func TestWithLoggerLogsLifecycleHooks(t *testing.T) {
+	t.Parallel()
+
+	logs := &logRecords{}
+	cnt, err := godi.NewContainer(godi.WithLogger(logs.logger()), godi.WithDefaultLifecycle())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var lifecycle *godi.Lifecycle
+	if err := cnt.Invoke(func(l *godi.Lifecycle) { lifecycle = l }); err != nil {
+		t.Fatalf("unexpected invoke error: %v", err)
+	}
+	lifecycle.Append(godi.Hook{
+		OnStart: func(context.Context) error { return nil },
+		OnStop:  func(context.Context) error { return errors.New("boom") },
+	})
+
+	if err := lifecycle.Start(t.Context()); err != nil {
+		t.Fatalf("unexpected start error: %v", err)
+	}
+	if err := lifecycle.Stop(t.Context()); err == nil {
+		t.Fatalf("expected stop error")
+	}
+
+	started := logs.find(t, "godi: hook start", "", "")
+	if started["level"] != "INFO" || started[godi.LogKeyHook] != float64(0) {
+		t.Fatalf("unexpected start record: %v", started)
+	}
+	stopped := logs.find(t, "godi: hook stop failed", godi.LogKeyError, "boom")
+	if stopped["level"] != "ERROR" {
+		t.Fatalf("unexpected stop record: %v", stopped)
+	}
+}
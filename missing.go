@@ -0,0 +1,75 @@
+package godi
+
+import "reflect"
+
+// MissingDependency is a required slot no provider satisfies, as marked by Missing graph edges.
+type MissingDependency struct {
+	// Scope is "root" or the module whose graph reports the edge.
+	Scope string
+	// Consumer labels the constructor or decorator that requires the slot.
+	Consumer string
+	Type     reflect.Type
+	Name     string
+	Lazy     bool
+}
+
+// Missing reports required dependencies without a provider, each in the scope where its consumer resolves
+// (root, then modules by name).
+// Optional dependencies and groups (which resolve to empty slices) are not reported.
+func (c *Container) Missing() []MissingDependency {
+	edges := c.missingEdges()
+	result := make([]MissingDependency, 0, len(edges))
+	for _, edge := range edges {
+		result = append(result, edge.MissingDependency)
+	}
+	return result
+}
+
+// missingEdge is a MissingDependency with the graph node of its consumer.
+type missingEdge struct {
+	MissingDependency
+	consumer ProviderNode
+}
+
+func (c *Container) missingEdges() []missingEdge {
+	return missingEdgesOf(c.GraphModules())
+}
+
+func missingEdgesOf(graphs map[string]Graph) []missingEdge {
+	result := make([]missingEdge, 0)
+	for _, scope := range sortedScopeNames(graphs) {
+		graph := graphs[scope]
+		nodes := make(map[string]ProviderNode, len(graph.Providers))
+		for _, node := range graph.Providers {
+			nodes[node.ID] = node
+		}
+
+		for _, edge := range graph.Edges {
+			if !edge.Missing || edge.Optional || edge.Group != "" {
+				continue
+			}
+			node := nodes[edge.From]
+			// Module providers resolve in their module scope, root providers in the root scope.
+			if node.Module != scope && (node.Module != "" || scope != rootScopeName) {
+				continue
+			}
+			for _, token := range node.Requires {
+				if token.typ == nil || token.Type != edge.Type || token.Name != edge.Name || token.Lazy != edge.Lazy {
+					continue
+				}
+				result = append(result, missingEdge{
+					MissingDependency: MissingDependency{
+						Scope:    scope,
+						Consumer: providerNodeLabel(node),
+						Type:     token.typ,
+						Name:     token.Name,
+						Lazy:     token.Lazy,
+					},
+					consumer: node,
+				})
+				break
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,66 @@
+package godi_test
+
+import (
+	"io"
+	"reflect"
+	"testing"
+
+	"go.uber.org/dig"
+
+	"github.com/assurrussa/godi"
+)
+
+func TestContainerMissing(t *testing.T) {
+	t.Parallel()
+
+	type params struct {
+		dig.In
+
+		Reader   io.Reader
+		Writer   io.Writer `name:"out"`
+		Closer   io.Closer `optional:"true"`
+		Handlers []string  `group:"handlers"`
+	}
+
+	cnt, err := godi.NewContainer(
+		godi.WithDependencies(godi.NewSingleDependency(func(params) int { return 0 })),
+		godi.WithModules(godi.NewModule("storage", godi.NewSingleDependency(
+			func(godi.Lazy[io.Reader]) float64 { return 0 }, godi.Private(),
+		))),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	missing := cnt.Missing()
+	if len(missing) != 3 {
+		t.Fatalf("expected 3 missing dependencies, got %+v", missing)
+	}
+	if missing[0].Scope != "root" || missing[0].Type != reflect.TypeFor[io.Reader]() || missing[0].Name != "" {
+		t.Fatalf("unexpected first missing dependency: %+v", missing[0])
+	}
+	if missing[1].Type != reflect.TypeFor[io.Writer]() || missing[1].Name != "out" {
+		t.Fatalf("unexpected named missing dependency: %+v", missing[1])
+	}
+	if missing[2].Scope != "storage" || !missing[2].Lazy || missing[2].Type != reflect.TypeFor[io.Reader]() {
+		t.Fatalf("unexpected module missing dependency: %+v", missing[2])
+	}
+	if cnt.Validate() == nil {
+		t.Fatal("expected validation error")
+	}
+}
+
+func TestContainerMissingIgnoresModulePrivateProviders(t *testing.T) {
+	t.Parallel()
+
+	cnt, err := godi.NewContainer(godi.WithModules(godi.NewModule("storage", godi.CollectDependencies(
+		godi.NewDependency(func() string { return "dsn" }, godi.Private()),
+		godi.NewDependency(func(string) int { return 0 }),
+	))))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if missing := cnt.Missing(); len(missing) != 0 {
+		t.Fatalf("expected no missing dependencies, got %+v", missing)
+	}
+}
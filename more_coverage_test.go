@@ -25,7 +25,7 @@ func TestWithKeyAffectsGraphNodeIDAndKeyField(t *testing.T) {
 	if g.Providers[0].Key != "k1" {
 		t.Fatalf("expected graph node key k1, got %q", g.Providers[0].Key)
 	}
-	if !strings.HasPrefix(g.Providers[0].ID, "key:k1#") {
+	if g.Providers[0].ID != "key:k1" {
 		t.Fatalf("expected provider ID to use key, got %q", g.Providers[0].ID)
 	}
 }
@@ -5,28 +5,85 @@ import (
 	"runtime"
 )
 
+const rootScopeName = "root"
+
+// Override kinds reported in OverrideInfo.Kind.
+const (
+	OverrideKindReplace  = "replace"
+	OverrideKindShadow   = "shadow"
+	OverrideKindDecorate = "decorate"
+)
+
 type ProviderInfo struct {
-	Index       int
-	Constructor string
-	File        string
-	Line        int
-	Type        string
-	Name        string
-	Group       string
+	Index       int    `json:"index"`
+	Module      string `json:"module,omitempty"`
+	Constructor string `json:"constructor,omitempty"`
+	File        string `json:"file,omitempty"`
+	Line        int    `json:"line,omitempty"`
+	Type        string `json:"type"`
+	Name        string `json:"name,omitempty"`
+	Group       string `json:"group,omitempty"`
 }
 
 type OverrideInfo struct {
-	Key      string
-	Previous ProviderInfo
-	Next     ProviderInfo
+	Key      string       `json:"key"`
+	Scope    string       `json:"scope"`
+	Kind     string       `json:"kind"`
+	Previous ProviderInfo `json:"previous"`
+	Next     ProviderInfo `json:"next"`
 }
 
 // DetectOverrides reports explicit replacements (godi.Replace) by slot.
 func DetectOverrides(deps Dependencies) []OverrideInfo {
+	return detectReplacements(buildRootEntries(deps.List()), rootScopeName)
+}
+
+// Overrides reports slot overrides across the root scope and every module scope:
+// explicit replacements, module-private providers shadowing root slots and decorators.
+// Group members accumulate instead of replacing each other, so they are never reported.
+func (c *Container) Overrides() []OverrideInfo {
+	rootEntries := buildRootEntries(c.dependencies)
+	moduleResolutions, err := buildModuleResolutions(c.modules)
+	if err != nil {
+		return DetectOverrides(CollectDependencies(c.dependencies...))
+	}
+
+	globalResolution, err := resolveEntries(buildGlobalEntries(rootEntries, moduleResolutions))
+	if err != nil {
+		return DetectOverrides(CollectDependencies(c.dependencies...))
+	}
+
+	// Module providers are appended in module order (not map order) to keep the report stable.
+	globalEntries := append([]depEntry{}, rootEntries...)
+	for _, module := range c.modules {
+		for _, provider := range moduleResolutions[module.Name].providers {
+			if !provider.dep.private {
+				globalEntries = append(globalEntries, provider)
+			}
+		}
+	}
+
+	overrides := detectReplacements(globalEntries, rootScopeName)
+	overrides = append(overrides, detectDecorations(globalResolution.decorators, globalResolution.slots, rootScopeName)...)
+
+	for _, module := range c.modules {
+		res := moduleResolutions[module.Name]
+		overrides = append(overrides, detectReplacements(buildModuleEntries(module), module.Name)...)
+
+		shadows, visible := detectShadowing(res.providers, globalResolution.slots, module.Name)
+		overrides = append(overrides, shadows...)
+		overrides = append(overrides, detectDecorations(res.decorators, visible, module.Name)...)
+	}
+
+	return overrides
+}
+
+func detectReplacements(entries []depEntry, scope string) []OverrideInfo {
 	seen := map[slotKey]ProviderInfo{}
 	overrides := make([]OverrideInfo, 0)
 
-	for i, dep := range deps.List() {
+	for _, entry := range entries {
+		dep := entry.dep
 		if dep.kind == dependencyKindDecorate {
 			continue
 		}
@@ -36,7 +93,7 @@ func DetectOverrides(deps Dependencies) []OverrideInfo {
 			continue
 		}
 
-		info := describeProvider(dep, i)
+		info := describeEntry(entry)
 		for _, slot := range slots {
 			if slot.group != "" {
 				continue
@@ -45,6 +102,8 @@ func DetectOverrides(deps Dependencies) []OverrideInfo {
 				if prev, ok := seen[slot]; ok {
 					overrides = append(overrides, OverrideInfo{
 						Key:      slotLabel(slot),
+						Scope:    scope,
+						Kind:     OverrideKindReplace,
 						Previous: prev,
 						Next:     info,
 					})
@@ -61,6 +120,84 @@ func DetectOverrides(deps Dependencies) []OverrideInfo {
 	return overrides
 }
 
+// detectShadowing reports module-private providers that hide a root slot inside the module scope.
+// It also returns the slots visible in the module scope, with private providers taking precedence.
+func detectShadowing(
+	providers []depEntry,
+	globalSlots map[slotKey]depEntry,
+	scope string,
+) ([]OverrideInfo, map[slotKey]depEntry) {
+	overrides := make([]OverrideInfo, 0)
+	visible := mergeSlots(globalSlots, nil)
+
+	for _, provider := range providers {
+		if !provider.dep.private {
+			continue
+		}
+		slots, err := dependencySlots(provider.dep)
+		if err != nil {
+			continue
+		}
+		for _, slot := range slots {
+			if slot.group != "" {
+				continue
+			}
+			if winner, ok := globalSlots[slot]; ok {
+				overrides = append(overrides, OverrideInfo{
+					Key:      slotLabel(slot),
+					Scope:    scope,
+					Kind:     OverrideKindShadow,
+					Previous: describeEntry(winner),
+					Next:     describeEntry(provider),
+				})
+			}
+			visible[slot] = provider
+		}
+	}
+
+	return overrides, visible
+}
+
+// detectDecorations reports each decorator applied to a slot, chained in declaration order.
+func detectDecorations(decorators []depEntry, available map[slotKey]depEntry, scope string) []OverrideInfo {
+	overrides := make([]OverrideInfo, 0)
+	current := map[slotKey]ProviderInfo{}
+
+	for _, decorator := range decorators {
+		slots, err := decoratorSlots(decorator.dep)
+		if err != nil {
+			continue
+		}
+		info := describeEntry(decorator)
+		for _, slot := range slots {
+			prev, ok := current[slot]
+			if !ok {
+				base, exists := available[slot]
+				if !exists {
+					continue
+				}
+				prev = describeEntry(base)
+			}
+			overrides = append(overrides, OverrideInfo{
+				Key:      slotLabel(slot),
+				Scope:    scope,
+				Kind:     OverrideKindDecorate,
+				Previous: prev,
+				Next:     info,
+			})
+			current[slot] = info
+		}
+	}
+
+	return overrides
+}
+
+func describeEntry(entry depEntry) ProviderInfo {
+	info := describeProvider(entry.dep, entry.idx)
+	info.Module = entry.module
+	return info
+}
+
 func describeProvider(dep Dependency, index int) ProviderInfo {
 	info := ProviderInfo{Index: index}
 
@@ -84,7 +221,11 @@ func describeProvider(dep Dependency, index int) ProviderInfo {
 }
 
 func describeEnrichFunc(dep Dependency, info *ProviderInfo) {
-	val := reflect.ValueOf(dep.constructor)
+	fn := dep.constructor
+	if dep.origin != nil {
+		fn = dep.origin
+	}
+	val := reflect.ValueOf(fn)
 	if val.Kind() == reflect.Func {
 		pc := val.Pointer()
 		if pc != 0 {
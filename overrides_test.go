@@ -28,3 +28,97 @@ func TestDetectOverridesReportsReplace(t *testing.T) {
 		t.Fatalf("unexpected override names: prev=%q next=%q", overrides[0].Previous.Name, overrides[0].Next.Name)
 	}
 }
+
+func TestContainerOverridesAcrossModules(t *testing.T) {
+	t.Parallel()
+
+	module := godi.NewModule("m", godi.CollectDependencies(
+		godi.NewDependency(func() int { return 1 }),
+		godi.Replace(func() int { return 2 }),
+		godi.NewDependency(func() string { return "secret" }, godi.Private()),
+		godi.Decorate(func(s string) string { return s + "!" }),
+	))
+
+	cnt, err := godi.NewContainer(
+		godi.WithDependencies(godi.CollectDependencies(
+			godi.NewDependency(func() string { return testBase }),
+			godi.Decorate(func(s string) string { return s + "?" }),
+		)),
+		godi.WithModules(module),
+	)
+	if err != nil {
+		t.Fatalf("NewContainer error: %v", err)
+	}
+
+	byKind := map[string][]godi.OverrideInfo{}
+	for _, o := range cnt.Overrides() {
+		byKind[o.Kind] = append(byKind[o.Kind], o)
+	}
+
+	replaces := byKind[godi.OverrideKindReplace]
+	if len(replaces) != 1 || replaces[0].Scope != "m" || replaces[0].Key != testTypeInt {
+		t.Fatalf("expected module-local int replacement, got %+v", replaces)
+	}
+	if replaces[0].Previous.Module != "m" || replaces[0].Next.Module != "m" {
+		t.Fatalf("expected both sides to come from module m, got %+v", replaces[0])
+	}
+
+	shadows := byKind[godi.OverrideKindShadow]
+	if len(shadows) != 1 || shadows[0].Scope != "m" || shadows[0].Key != testTypeString {
+		t.Fatalf("expected private string to shadow root slot, got %+v", shadows)
+	}
+	if shadows[0].Previous.Module != "" || shadows[0].Next.Module != "m" {
+		t.Fatalf("expected root provider shadowed by module provider, got %+v", shadows[0])
+	}
+	if shadows[0].Next.File == "" || shadows[0].Next.Line == 0 {
+		t.Fatalf("expected source location for shadowing provider, got %+v", shadows[0].Next)
+	}
+
+	decorates := byKind[godi.OverrideKindDecorate]
+	if len(decorates) != 2 {
+		t.Fatalf("expected 2 decorations, got %+v", decorates)
+	}
+	for _, d := range decorates {
+		switch d.Scope {
+		case "root":
+			if d.Previous.Module != "" || d.Previous.Index != 0 {
+				t.Fatalf("expected root decorator to wrap root provider, got %+v", d)
+			}
+		case "m":
+			if d.Previous.Module != "m" || d.Previous.Index != 2 {
+				t.Fatalf("expected module decorator to wrap private provider, got %+v", d)
+			}
+		default:
+			t.Fatalf("unexpected decoration scope %q", d.Scope)
+		}
+	}
+}
+
+func TestContainerOverridesModuleReplacesRoot(t *testing.T) {
+	t.Parallel()
+
+	module := godi.NewModule("m", godi.CollectDependencies(
+		godi.Replace(func() string { return testOverride }),
+		godi.NewDependency(func() int { return 1 }, godi.WithGroup("items")),
+	))
+
+	cnt, err := godi.NewContainer(
+		godi.WithDependencies(godi.CollectDependencies(
+			godi.NewDependency(func() string { return testBase }),
+			godi.NewDependency(func() int { return 0 }, godi.WithGroup("items")),
+		)),
+		godi.WithModules(module),
+	)
+	if err != nil {
+		t.Fatalf("NewContainer error: %v", err)
+	}
+
+	overrides := cnt.Overrides()
+	if len(overrides) != 1 {
+		t.Fatalf("expected 1 override (groups accumulate), got %+v", overrides)
+	}
+	o := overrides[0]
+	if o.Scope != "root" || o.Kind != godi.OverrideKindReplace || o.Previous.Module != "" || o.Next.Module != "m" {
+		t.Fatalf("unexpected override: %+v", o)
+	}
+}
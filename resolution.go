@@ -12,6 +12,8 @@ type depEntry struct {
 	dep    Dependency
 	idx    int
 	module string
+	// id is the graph node ID, see buildEntries.
+	id string
 }
 
 type slotKey struct {
@@ -41,13 +43,23 @@ type slotState struct {
 }
 
 func resolveEntries(entries []depEntry) (resolvedScope, error) {
+	result, errs := collectResolution(entries)
+	if len(errs) > 0 {
+		return resolvedScope{}, errs[0]
+	}
+	return result, nil
+}
+
+// collectResolution resolves entries like resolveEntries, but skips invalid entries and reports all of them.
+func collectResolution(entries []depEntry) (resolvedScope, []error) {
 	states := map[slotKey]*slotState{}
 	decorators := make([]depEntry, 0)
 
+	var errs []error
 	for i := range entries {
 		entry := entries[i]
 		if err := classifyEntry(entry, states, &decorators); err != nil {
-			return resolvedScope{}, err
+			errs = append(errs, err)
 		}
 	}
 
@@ -59,7 +71,7 @@ func resolveEntries(entries []depEntry) (resolvedScope, error) {
 	}
 	result.decorators = decorators
 
-	return result, nil
+	return result, errs
 }
 
 func classifyEntry(entry depEntry, states map[slotKey]*slotState, decorators *[]depEntry) error {
@@ -70,14 +82,17 @@ func classifyEntry(entry depEntry, states map[slotKey]*slotState, decorators *[]
 
 	if dep.kind == dependencyKindDecorate {
 		if err := validateDecorateDependency(dep); err != nil {
-			return err
+			return newOptionsError(entry, err)
 		}
 		*decorators = append(*decorators, entry)
 		return nil
 	}
 
 	if dependencyGroup(dep) != "" && dep.kind != dependencyKindProvide {
-		return errors.New("replace/decorate is not supported for group dependency")
+		return newOptionsError(entry, errors.New("replace/decorate is not supported for group dependency"))
+	}
+	if err := validateProvideOptions(dep); err != nil {
+		return newOptionsError(entry, err)
 	}
 
 	slots, err := dependencySlots(dep)
@@ -111,13 +126,17 @@ func applyToState(state *slotState, slot slotKey, entry depEntry) error {
 	switch entry.dep.kind {
 	case dependencyKindProvide:
 		if state.hasProvide {
-			return fmt.Errorf("duplicate provider for slot %s", slotLabel(slot))
+			err := newSlotError(WiringDuplicateProvider, slot, state.provide, entry)
+			if state.provide.module == entry.module {
+				err.Suggestion = "godi.Replace"
+			}
+			return err
 		}
 		state.provide = entry
 		state.hasProvide = true
 	case dependencyKindReplace:
 		if state.hasReplace {
-			return fmt.Errorf("duplicate replace for slot %s", slotLabel(slot))
+			return newSlotError(WiringDuplicateReplace, slot, state.replace, entry)
 		}
 		state.replace = entry
 		state.hasReplace = true
@@ -0,0 +1,85 @@
+package godi
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/dig"
+)
+
+// ConstructorTiming records one constructor or decorator call made by the container.
+type ConstructorTiming struct {
+	Constructor string        `json:"constructor"`
+	Module      string        `json:"module,omitempty"`
+	Kind        string        `json:"kind"`
+	Type        string        `json:"type,omitempty"`
+	Name        string        `json:"name,omitempty"`
+	Group       string        `json:"group,omitempty"`
+	Duration    time.Duration `json:"duration"`
+	Error       string        `json:"error,omitempty"`
+}
+
+// timingRecorder collects constructor timings and notifies observers through dig callbacks. It is shared by
+// every build of a container, so the records survive Provide; a nil recorder records nothing (used for dry runs).
+type timingRecorder struct {
+	mu        sync.Mutex
+	timings   []ConstructorTiming
+	observers []Observer
+	// dependencies is refreshed by every build that has observers; see eventDependencies.
+	dependencies map[string][]string
+}
+
+// ConstructorTimings returns constructor and decorator calls in the order they finished.
+// It is safe for concurrent use.
+func (c *Container) ConstructorTimings() []ConstructorTiming {
+	c.timings.mu.Lock()
+	defer c.timings.mu.Unlock()
+	return append([]ConstructorTiming(nil), c.timings.timings...)
+}
+
+func (r *timingRecorder) callback(entry depEntry) dig.Callback {
+	event := r.event(entry)
+	timing := ConstructorTiming{
+		Constructor: event.Constructor,
+		Module:      event.Module,
+		Kind:        event.Kind,
+		Type:        event.Type,
+		Name:        event.Name,
+		Group:       event.Group,
+	}
+	return func(call dig.CallbackInfo) {
+		record := timing
+		record.Duration = call.Runtime
+		if call.Error != nil {
+			record.Error = call.Error.Error()
+		}
+
+		r.mu.Lock()
+		r.timings = append(r.timings, record)
+		r.mu.Unlock()
+
+		r.observe(event, call)
+	}
+}
+
+func (r *timingRecorder) provideOptions(entry depEntry) []dig.ProvideOption {
+	if r == nil {
+		return nil
+	}
+	options := []dig.ProvideOption{dig.WithProviderCallback(r.callback(entry))}
+	if len(r.observers) > 0 {
+		options = append(options, dig.WithProviderBeforeCallback(r.beforeCallback(entry)))
+	}
+	return options
+}
+
+func (r *timingRecorder) decorateOptions(entry depEntry) []dig.DecorateOption {
+	if r == nil {
+		return nil
+	}
+	options := []dig.DecorateOption{dig.WithDecoratorCallback(r.callback(entry))}
+	if len(r.observers) > 0 {
+		options = append(options, dig.WithDecoratorBeforeCallback(r.beforeCallback(entry)))
+	}
+	return options
+}
@@ -0,0 +1,290 @@
+package godi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"runtime"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Span categories used in startup traces.
+const (
+	traceCategoryHook = "hook"
+
+	traceProcessID      = 1
+	traceCriticalThread = 0
+)
+
+// traceSpan is one recorded constructor, decorator or hook call.
+type traceSpan struct {
+	id           string
+	name         string
+	category     string
+	dependencies []string
+	args         map[string]any
+	start        time.Time
+	duration     time.Duration
+	goroutine    uint64
+	// stop marks a stop hook; shutdown is not part of the startup critical path.
+	stop bool
+}
+
+func (s traceSpan) end() time.Time {
+	return s.start.Add(s.duration)
+}
+
+// startupTracer records spans for WriteStartupTrace. It observes constructor calls like any Observer
+// and is notified about hook calls by the default lifecycle.
+type startupTracer struct {
+	mu    sync.Mutex
+	spans []traceSpan
+}
+
+func (t *startupTracer) ConstructorStarted(ConstructorEvent) {}
+
+func (t *startupTracer) ConstructorFinished(event ConstructorEvent) {
+	args := map[string]any{"id": event.ID, "slot": eventSlot(event)}
+	if event.Module != "" {
+		args["module"] = event.Module
+	}
+	if event.Error != nil {
+		args["error"] = event.Error.Error()
+	}
+	name := event.Constructor
+	if name == "" {
+		name = eventSlot(event)
+	}
+	t.add(traceSpan{
+		id:           event.ID,
+		name:         name,
+		category:     event.Kind,
+		dependencies: event.Dependencies,
+		args:         args,
+		start:        event.Start,
+		duration:     event.Duration,
+		goroutine:    goroutineID(),
+	})
+}
+
+func (t *startupTracer) hook(action string, index int, started time.Time, err error) {
+	if t == nil {
+		return
+	}
+	args := map[string]any{"index": index}
+	if err != nil {
+		args["error"] = err.Error()
+	}
+	t.add(traceSpan{
+		id:        "hook/" + strconv.Itoa(index) + "/" + action,
+		name:      "hook " + strconv.Itoa(index) + " " + action,
+		category:  traceCategoryHook,
+		args:      args,
+		start:     started,
+		duration:  time.Since(started),
+		goroutine: goroutineID(),
+		stop:      action == "stop",
+	})
+}
+
+func (t *startupTracer) add(span traceSpan) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.spans = append(t.spans, span)
+}
+
+func (t *startupTracer) snapshot() []traceSpan {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]traceSpan(nil), t.spans...)
+}
+
+// traceEvent is an entry of the Chrome trace_event format.
+type traceEvent struct {
+	Name     string         `json:"name"`
+	Category string         `json:"cat,omitempty"`
+	Phase    string         `json:"ph"`
+	Time     float64        `json:"ts"`
+	Duration float64        `json:"dur,omitempty"`
+	Process  int            `json:"pid"`
+	Thread   uint64         `json:"tid"`
+	Args     map[string]any `json:"args,omitempty"`
+}
+
+type traceFile struct {
+	TraceEvents     []traceEvent   `json:"traceEvents"`
+	DisplayTimeUnit string         `json:"displayTimeUnit"`
+	OtherData       map[string]any `json:"otherData"`
+}
+
+// WriteStartupTrace writes the constructor and decorator calls and the default lifecycle hook calls recorded
+// since the container was created as Chrome trace_event JSON, viewable in chrome://tracing or Perfetto.
+// Every goroutine that ran a call gets its own track; hooks run one after another on the goroutine that called
+// Start or Stop. The critical path over constructors, decorators and start hooks is repeated on a separate
+// "critical path" track. The container must be created with WithStartupTrace.
+func (c *Container) WriteStartupTrace(w io.Writer) error {
+	if c.tracer == nil {
+		return errors.New("startup trace is not recorded, use WithStartupTrace")
+	}
+
+	spans := c.tracer.snapshot()
+	sort.SliceStable(spans, func(i, j int) bool { return spans[i].start.Before(spans[j].start) })
+	var origin time.Time
+	if len(spans) > 0 {
+		origin = spans[0].start
+	}
+	micros := func(d time.Duration) float64 { return float64(d.Nanoseconds()) / float64(time.Microsecond) }
+
+	events := []traceEvent{
+		{Name: "process_name", Phase: "M", Process: traceProcessID, Args: map[string]any{"name": "godi startup"}},
+		{
+			Name: "thread_name", Phase: "M", Process: traceProcessID, Thread: traceCriticalThread,
+			Args: map[string]any{"name": "critical path"},
+		},
+	}
+	threads := map[uint64]bool{}
+	for _, span := range spans {
+		if !threads[span.goroutine] {
+			threads[span.goroutine] = true
+			events = append(events, traceEvent{
+				Name: "thread_name", Phase: "M", Process: traceProcessID, Thread: span.goroutine,
+				Args: map[string]any{"name": "goroutine " + strconv.FormatUint(span.goroutine, 10)},
+			})
+		}
+		events = append(events, traceEvent{
+			Name:     span.name,
+			Category: span.category,
+			Phase:    "X",
+			Time:     micros(span.start.Sub(origin)),
+			Duration: micros(span.duration),
+			Process:  traceProcessID,
+			Thread:   span.goroutine,
+			Args:     span.args,
+		})
+	}
+
+	path := criticalPath(spans)
+	names := make([]string, 0, len(path))
+	var total time.Duration
+	for _, span := range path {
+		names = append(names, span.name)
+		total += span.duration
+		events = append(events, traceEvent{
+			Name:     span.name,
+			Category: span.category,
+			Phase:    "X",
+			Time:     micros(span.start.Sub(origin)),
+			Duration: micros(span.duration),
+			Process:  traceProcessID,
+			Thread:   traceCriticalThread,
+			Args:     span.args,
+		})
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(traceFile{
+		TraceEvents:     events,
+		DisplayTimeUnit: "ms",
+		OtherData: map[string]any{
+			"criticalPath":         names,
+			"criticalPathDuration": total.String(),
+		},
+	}); err != nil {
+		return err
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// criticalPath returns the chain of startup calls with the largest total duration, in execution order. A
+// constructor or decorator follows its dependencies; a start hook follows any call that finished before it
+// started, since hooks run after the values they close over are built and after the previous hook. Stop hooks
+// are left out.
+func criticalPath(all []traceSpan) []traceSpan {
+	spans := make([]traceSpan, 0, len(all))
+	for _, span := range all {
+		if !span.stop {
+			spans = append(spans, span)
+		}
+	}
+
+	byID := make(map[string]int, len(spans))
+	for i, span := range spans {
+		byID[span.id] = i
+	}
+
+	predecessors := func(i int) []int {
+		span := spans[i]
+		result := []int{}
+		if span.category == traceCategoryHook {
+			for j, candidate := range spans[:i] {
+				if !candidate.end().After(span.start) {
+					result = append(result, j)
+				}
+			}
+			return result
+		}
+		for _, dep := range span.dependencies {
+			if j, ok := byID[dep]; ok && j < i && !spans[j].end().After(span.start) {
+				result = append(result, j)
+			}
+		}
+		return result
+	}
+
+	// Spans are recorded when they finish, so a predecessor always comes earlier in the slice. Timestamps
+	// alone are not enough: calls that take no measurable time share one and would precede each other.
+	weights := make([]time.Duration, len(spans))
+	previous := make([]int, len(spans))
+	done := make([]bool, len(spans))
+	var weigh func(i int) time.Duration
+	weigh = func(i int) time.Duration {
+		if done[i] {
+			return weights[i]
+		}
+		previous[i] = -1
+		var best time.Duration
+		for _, j := range predecessors(i) {
+			if w := weigh(j); previous[i] < 0 || w > best {
+				best, previous[i] = w, j
+			}
+		}
+		weights[i] = best + spans[i].duration
+		done[i] = true
+		return weights[i]
+	}
+
+	last := -1
+	for i := range spans {
+		if last < 0 || weigh(i) > weights[last] {
+			last = i
+		}
+	}
+
+	path := []traceSpan{}
+	for i := last; i >= 0; i = previous[i] {
+		path = append(path, spans[i])
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// goroutineID parses the current goroutine ID from the runtime stack header ("goroutine 18 [running]:").
+func goroutineID() uint64 {
+	var buf [64]byte
+	header := buf[:runtime.Stack(buf[:], false)]
+	header = bytes.TrimPrefix(header, []byte("goroutine "))
+	if i := bytes.IndexByte(header, ' '); i >= 0 {
+		header = header[:i]
+	}
+	id, _ := strconv.ParseUint(string(header), 10, 64)
+	return id
+}
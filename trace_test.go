@@ -0,0 +1,163 @@
+package godi_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/assurrussa/godi"
+)
+
+type (
+	tracedConfig struct{}
+	tracedCache  struct{}
+	tracedDB     struct{}
+	tracedServer struct{}
+)
+
+func newTracedConfig() tracedConfig { return tracedConfig{} }
+
+func newTracedCache() *tracedCache {
+	time.Sleep(time.Millisecond)
+	return &tracedCache{}
+}
+
+func newTracedDB(tracedConfig) *tracedDB {
+	time.Sleep(20 * time.Millisecond)
+	return &tracedDB{}
+}
+
+func newTracedServer(*tracedDB, *tracedCache) *tracedServer { return &tracedServer{} }
+
+type traceOutput struct {
+	TraceEvents []struct {
+		Name     string         `json:"name"`
+		Category string         `json:"cat"`
+		Phase    string         `json:"ph"`
+		Time     float64        `json:"ts"`
+		Duration float64        `json:"dur"`
+		Thread   uint64         `json:"tid"`
+		Args     map[string]any `json:"args"`
+	} `json:"traceEvents"`
+	OtherData struct {
+		CriticalPath []string `json:"criticalPath"`
+	} `json:"otherData"`
+}
+
+func TestWriteStartupTrace(t *testing.T) {
+	t.Parallel()
+
+	cnt, err := godi.NewContainer(
+		godi.WithStartupTrace(),
+		godi.WithDefaultLifecycle(),
+		godi.WithModules(godi.NewModule("storage", godi.CollectDependencies(
+			godi.NewDependency(newTracedConfig, godi.Private()),
+			godi.NewDependency(newTracedDB),
+		))),
+		godi.WithDependencies(godi.CollectDependencies(
+			godi.NewDependency(newTracedCache),
+			godi.NewDependency(newTracedServer),
+		)),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var lifecycle *godi.Lifecycle
+	if err := cnt.Invoke(func(_ *tracedServer, l *godi.Lifecycle) { lifecycle = l }); err != nil {
+		t.Fatalf("unexpected invoke error: %v", err)
+	}
+	for range 2 {
+		lifecycle.Append(godi.Hook{
+			OnStart: func(context.Context) error { return nil },
+			OnStop:  func(context.Context) error { return nil },
+		})
+	}
+
+	// Hooks run one after another on the goroutine that calls Start or Stop, so starting from another
+	// goroutine and stopping from the test puts the two runs on separate tracks.
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := lifecycle.Start(context.Background()); err != nil {
+			t.Errorf("unexpected start error: %v", err)
+		}
+	}()
+	wg.Wait()
+	if err := lifecycle.Stop(t.Context()); err != nil {
+		t.Fatalf("unexpected stop error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := cnt.WriteStartupTrace(&buf); err != nil {
+		t.Fatalf("unexpected trace error: %v", err)
+	}
+	var out traceOutput
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid trace json: %v\n%s", err, buf.String())
+	}
+
+	hookThreads := map[uint64]bool{}
+	var db bool
+	for _, event := range out.TraceEvents {
+		if event.Phase != "X" || event.Thread == 0 {
+			continue
+		}
+		if event.Category == "hook" {
+			hookThreads[event.Thread] = true
+		}
+		if strings.HasSuffix(event.Name, ".newTracedDB") {
+			db = true
+			if event.Args["module"] != "storage" || event.Duration < 20000 {
+				t.Fatalf("unexpected db event: %+v", event)
+			}
+		}
+	}
+	if !db {
+		t.Fatalf("db constructor missing from trace:\n%s", buf.String())
+	}
+	if len(hookThreads) != 2 {
+		t.Fatalf("expected hooks on 2 goroutines, got %v", hookThreads)
+	}
+
+	// Stop hooks are not part of startup and stay off the critical path.
+	want := []string{".newTracedConfig", ".newTracedDB", ".newTracedServer", "hook 0 start", "hook 1 start"}
+	if len(out.OtherData.CriticalPath) != len(want) {
+		t.Fatalf("unexpected critical path: %v", out.OtherData.CriticalPath)
+	}
+	for i, name := range want {
+		if !strings.HasSuffix(out.OtherData.CriticalPath[i], name) {
+			t.Fatalf("unexpected critical path: %v", out.OtherData.CriticalPath)
+		}
+	}
+}
+
+func TestWriteStartupTraceRequiresOption(t *testing.T) {
+	t.Parallel()
+
+	cnt, err := godi.NewContainer()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cnt.WriteStartupTrace(&bytes.Buffer{}); err == nil {
+		t.Fatalf("expected error without WithStartupTrace")
+	}
+}
+
+func TestCriticalPathWithZeroDurationHooks(t *testing.T) {
+	t.Parallel()
+
+	// Hooks that take no measurable time share a timestamp, so each one ends when the others start.
+	path := godi.HookCriticalPath(0, 0, time.Millisecond, 0)
+	if len(path) == 0 || path[len(path)-1] != "hook 2 start" {
+		t.Fatalf("expected the path to end at the only measurable hook, got %v", path)
+	}
+	if path := godi.HookCriticalPath(0, 0, 0); len(path) != 1 || path[0] != "hook 0 start" {
+		t.Fatalf("expected a single hook for a path of zero weight, got %v", path)
+	}
+}
@@ -0,0 +1,182 @@
+package godi
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// UnusedReport lists providers that no root consumer can reach and matchings that never matched.
+type UnusedReport struct {
+	Providers []ProviderNode `json:"providers"`
+	Matchings []string       `json:"matchings"`
+}
+
+// Empty reports whether nothing unused was found.
+func (r UnusedReport) Empty() bool {
+	return len(r.Providers) == 0 && len(r.Matchings) == 0
+}
+
+func (r UnusedReport) String() string {
+	parts := make([]string, 0, 2)
+	if len(r.Providers) > 0 {
+		labels := make([]string, 0, len(r.Providers))
+		for _, p := range r.Providers {
+			labels = append(labels, providerNodeLabel(p))
+		}
+		parts = append(parts, "unused providers: "+strings.Join(labels, ", "))
+	}
+	if len(r.Matchings) > 0 {
+		parts = append(parts, "unused matchings: "+strings.Join(r.Matchings, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Unused walks graph edges starting from the given consumers (functions, as passed to Invoke)
+// and all registered runnables, and reports providers that are never reachable.
+// It also reports matchings (WithMatchings) that did not match any dependency.
+// Without roots, only runnables are roots; pass UnusedRoots to use the consumers registered with WithUnusedCheck.
+func (c *Container) Unused(roots ...any) (UnusedReport, error) {
+	seeds := make([]GraphToken, 0)
+	for i, root := range roots {
+		fnType := reflect.TypeOf(root)
+		if fnType == nil || fnType.Kind() != reflect.Func {
+			return UnusedReport{}, fmt.Errorf("root consumer must be a function, got %T at index %d", root, i)
+		}
+		seeds = append(seeds, parseConstructorInputs(root)...)
+	}
+	seeds = append(seeds, GraphToken{Type: reflect.TypeFor[Runnable]().String(), Group: runnableGroup})
+
+	graphs := c.GraphModules()
+	root := graphs[rootScopeName]
+
+	visited := map[string]bool{}
+	queue := make([]ProviderNode, 0)
+	visit := func(node ProviderNode) {
+		key := qualifiedNodeID(node)
+		if visited[key] {
+			return
+		}
+		visited[key] = true
+		queue = append(queue, node)
+	}
+
+	for _, seed := range seeds {
+		for _, node := range root.Providers {
+			if providesToken(node, seed) {
+				visit(node)
+			}
+		}
+	}
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		// Module providers resolve their inputs inside their own scope.
+		scope := rootScopeName
+		if node.Module != "" {
+			scope = node.Module
+		}
+		graph, ok := graphs[scope]
+		if !ok {
+			continue
+		}
+		for _, edge := range graph.Edges {
+			if edge.From != node.ID || edge.Missing {
+				continue
+			}
+			if target, ok := findProvider(graph, edge.To); ok {
+				visit(target)
+			}
+		}
+	}
+
+	report := UnusedReport{}
+	seen := map[string]bool{}
+	scopes := []string{rootScopeName}
+	for _, module := range c.modules {
+		scopes = append(scopes, module.Name)
+	}
+	for _, scope := range scopes {
+		for _, node := range graphs[scope].Providers {
+			key := qualifiedNodeID(node)
+			if visited[key] || seen[key] {
+				continue
+			}
+			seen[key] = true
+			report.Providers = append(report.Providers, node)
+		}
+	}
+
+	report.Matchings = c.unusedMatchings()
+
+	return report, nil
+}
+
+// UnusedRoots returns the consumers registered with WithUnusedCheck, the roots Validate passes to Unused.
+func (c *Container) UnusedRoots() []any {
+	return append([]any(nil), c.unusedRoots...)
+}
+
+func (c *Container) unusedMatchings() []string {
+	deps := append([]Dependency{}, c.dependencies...)
+	for _, module := range c.modules {
+		deps = append(deps, module.Dependencies.List()...)
+	}
+
+	unused := make([]string, 0)
+	for _, matching := range c.matchings {
+		matched := false
+		for _, dep := range deps {
+			if dep.kind == dependencyKindDecorate {
+				continue
+			}
+			interfaces, err := matchingInterfaces(dep, matching)
+			if err == nil && len(interfaces) > 0 {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			unused = append(unused, matchingLabel(matching))
+		}
+	}
+	return unused
+}
+
+func providesToken(node ProviderNode, token GraphToken) bool {
+	for _, provided := range node.Provides {
+		if provided.Type == token.Type && provided.Name == token.Name && provided.Group == token.Group {
+			return true
+		}
+	}
+	return false
+}
+
+func findProvider(graph Graph, id string) (ProviderNode, bool) {
+	for _, node := range graph.Providers {
+		if node.ID == id {
+			return node, true
+		}
+	}
+	return ProviderNode{}, false
+}
+
+func matchingLabel(matching any) string {
+	if m, ok := matching.(Matching); ok {
+		labels := make([]string, 0, len(m.interfaces))
+		for _, iface := range m.interfaces {
+			labels = append(labels, reflect.TypeOf(iface).Elem().String())
+		}
+		origin := "<invalid>"
+		if m.origin != nil {
+			origin = m.origin.String()
+		}
+		return fmt.Sprintf("%s -> [%s]", origin, strings.Join(labels, ", "))
+	}
+	if t := reflect.TypeOf(matching); t != nil && t.Kind() == reflect.Pointer {
+		return t.Elem().String()
+	}
+	return fmt.Sprintf("%T", matching)
+}
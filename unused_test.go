@@ -0,0 +1,117 @@
+package godi_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/assurrussa/godi"
+)
+
+func TestUnusedReportsUnreachableProviders(t *testing.T) {
+	t.Parallel()
+
+	module := godi.NewModule("m", godi.CollectDependencies(
+		godi.NewDependency(func() string { return "secret" }, godi.Private()),
+		godi.NewDependency(func(s string) int { return len(s) }),
+		godi.NewDependency(func() bool { return true }, godi.Private()),
+	))
+
+	cnt, err := godi.NewContainer(
+		godi.WithDependencies(godi.CollectDependencies(
+			godi.NewDependency(func() float64 { return 1 }),
+			godi.NewDependency(func() uint { return 1 }, godi.WithKey("dead")),
+			godi.NewDependency(func(f float64) godi.Runnable {
+				return godi.Runnable{OnStart: func(context.Context) error { _ = f; return nil }}
+			}),
+		)),
+		godi.WithModules(module),
+		godi.WithMatchings(new(io.Reader)),
+	)
+	if err != nil {
+		t.Fatalf("NewContainer error: %v", err)
+	}
+
+	report, err := cnt.Unused(func(int) {})
+	if err != nil {
+		t.Fatalf("Unused error: %v", err)
+	}
+
+	got := map[string]bool{}
+	for _, p := range report.Providers {
+		got[p.Module+":"+p.Type] = true
+	}
+	if len(report.Providers) != 2 || !got[":uint"] || !got["m:bool"] {
+		t.Fatalf("expected unused uint and module-private bool, got %v", got)
+	}
+	if len(report.Matchings) != 1 || report.Matchings[0] != "io.Reader" {
+		t.Fatalf("expected unused io.Reader matching, got %v", report.Matchings)
+	}
+
+	if _, err := cnt.Unused("not-a-func"); err == nil {
+		t.Fatal("expected error for non-function root")
+	}
+}
+
+func TestWithUnusedCheckFailsValidate(t *testing.T) {
+	t.Parallel()
+
+	cnt, err := godi.NewContainer(
+		godi.WithDependencies(godi.CollectDependencies(
+			godi.NewDependency(func() string { return "used" }),
+			godi.NewDependency(func() int { return 1 }),
+		)),
+		godi.WithUnusedCheck(func(string) {}),
+	)
+	if err != nil {
+		t.Fatalf("NewContainer error: %v", err)
+	}
+
+	err = cnt.Validate()
+	if err == nil || !strings.Contains(err.Error(), "unused providers") {
+		t.Fatalf("expected unused providers validation error, got %v", err)
+	}
+
+	ok, err := godi.NewContainer(
+		godi.WithDependencies(godi.NewSingleDependency(func() string { return "used" })),
+		godi.WithUnusedCheck(func(string) {}),
+	)
+	if err != nil {
+		t.Fatalf("NewContainer error: %v", err)
+	}
+	if err := ok.Validate(); err != nil {
+		t.Fatalf("expected validation to pass, got %v", err)
+	}
+}
+
+func TestUnusedWithoutRootsStartsFromRunnables(t *testing.T) {
+	t.Parallel()
+
+	cnt, err := godi.NewContainer(
+		godi.WithDependencies(godi.CollectDependencies(
+			godi.NewDependency(func() string { return "used" }),
+			godi.NewDependency(func() int { return 1 }),
+		)),
+		godi.WithUnusedCheck(func(string) {}),
+	)
+	if err != nil {
+		t.Fatalf("NewContainer error: %v", err)
+	}
+
+	report, err := cnt.Unused()
+	if err != nil {
+		t.Fatalf("Unused error: %v", err)
+	}
+	if len(report.Providers) != 2 {
+		t.Fatalf("expected both providers without roots, got %v", report.Providers)
+	}
+
+	report, err = cnt.Unused(cnt.UnusedRoots()...)
+	if err != nil {
+		t.Fatalf("Unused error: %v", err)
+	}
+	if len(report.Providers) != 1 || report.Providers[0].Type != "int" {
+		t.Fatalf("expected only int with the WithUnusedCheck roots, got %v", report.Providers)
+	}
+}
@@ -0,0 +1,147 @@
+package godi
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// ValidationError holds every wiring problem found by ValidateAll. Its message starts with a summary
+// table followed by the numbered errors; errors.As and errors.Is see each collected error.
+type ValidationError struct {
+	Errors []error
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	b.WriteString(strconv.Itoa(len(e.Errors)) + " wiring problem(s):\n")
+
+	table := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(table, "#\tKIND\tMODULE\tSLOT\tPROVIDER")
+	for i, err := range e.Errors {
+		kind, module, slot, provider := "error", "-", "-", "-"
+		var wiringErr *WiringError
+		if errors.As(err, &wiringErr) {
+			kind = string(wiringErr.Kind)
+			if wiringErr.Module != "" {
+				module = wiringErr.Module
+			}
+			if wiringErr.Slot != "" {
+				slot = wiringErr.Slot
+			}
+			if len(wiringErr.Providers) > 0 {
+				provider = providerInfoLabel(wiringErr.Providers[0])
+			}
+		}
+		_, _ = fmt.Fprintf(table, "%d\t%s\t%s\t%s\t%s\n", i+1, kind, module, slot, provider)
+	}
+	_ = table.Flush()
+
+	for i, err := range e.Errors {
+		_, _ = fmt.Fprintf(&b, "\n%d. %v", i+1, err)
+	}
+	return b.String()
+}
+
+func (e *ValidationError) Unwrap() []error {
+	return e.Errors
+}
+
+// ValidateAll checks the same things as Validate but reports every problem instead of the first one:
+//   - invalid options, duplicate providers and replacements and decorators without a provider, in the root
+//     scope and every module;
+//   - dependency cycles and missing providers (one error per slot, listing all of its consumers);
+//   - failed WithCheck checks, the dry-run invocations of Validate and the unused check.
+//
+// Cycles and missing providers are found on the graphs of what resolves, so a duplicate provider does not
+// hide them. It returns nil or a *ValidationError.
+func (c *Container) ValidateAll() error {
+	errs := c.wiringProblems()
+	if len(errs) == 0 {
+		return nil
+	}
+	return &ValidationError{Errors: errs}
+}
+
+func (c *Container) wiringProblems() []error {
+	var errs []error
+
+	moduleResolutions := map[string]resolvedScope{}
+	for _, module := range c.modules {
+		res, moduleErrs := collectResolution(buildModuleEntries(module))
+		errs = append(errs, moduleErrors(module.Name, moduleErrs)...)
+		moduleResolutions[module.Name] = res
+	}
+
+	global, globalErrs := collectResolution(buildGlobalEntries(buildRootEntries(c.dependencies), moduleResolutions))
+	errs = append(errs, globalErrs...)
+	errs = append(errs, decoratorErrors(global.decorators, global.slots)...)
+	for _, module := range c.modules {
+		res := moduleResolutions[module.Name]
+		available := mergeSlots(res.slots, global.slots)
+		errs = append(errs, moduleErrors(module.Name, decoratorErrors(res.decorators, available))...)
+	}
+
+	graphs := scopeGraphs(global, moduleResolutions)
+	errs = append(errs, cycleErrors(graphs)...)
+
+	edges := missingEdgesOf(graphs)
+	reported := map[string]bool{}
+	for _, edge := range edges {
+		key := edge.Scope + "\x00" + slotLabel(slotKey{t: edge.Type, name: edge.Name})
+		// A decorator's input for the slot it decorates is reported as an undecoratable slot.
+		if reported[key] || decoratesOwnInput(edge) {
+			continue
+		}
+		reported[key] = true
+		err := error(newMissingError(edge, edges, global, moduleResolutions, nil))
+		if edge.Scope != rootScopeName {
+			err = fmt.Errorf("module %s: %w", edge.Scope, err)
+		}
+		errs = append(errs, err)
+	}
+
+	// The dry run needs a wiring that resolves; otherwise its failure is one of the problems above.
+	built, err := c.build(true)
+	if err != nil {
+		if len(errs) == 0 {
+			errs = append(errs, err)
+		}
+		return errs
+	}
+	seen := map[string]bool{}
+	c.checkBuilt(built, func(err error) bool {
+		var wiringErr *WiringError
+		if errors.As(err, &wiringErr) && wiringErr.Kind == WiringMissingProvider {
+			return true
+		}
+		if !seen[err.Error()] {
+			seen[err.Error()] = true
+			errs = append(errs, err)
+		}
+		return true
+	})
+	return errs
+}
+
+func decoratesOwnInput(edge missingEdge) bool {
+	if edge.consumer.Kind != dependencyKindString(dependencyKindDecorate) {
+		return false
+	}
+	for _, token := range edge.consumer.Provides {
+		if token.Type == edge.Type.String() && token.Name == edge.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func moduleErrors(module string, errs []error) []error {
+	result := make([]error, 0, len(errs))
+	for _, err := range errs {
+		result = append(result, fmt.Errorf("module %s: %w", module, err))
+	}
+	return result
+}
@@ -0,0 +1,157 @@
+package godi_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/assurrussa/godi"
+)
+
+type (
+	allCache   struct{}
+	allQueue   struct{}
+	allService struct{}
+)
+
+func newAllCache() *allCache { return &allCache{} }
+
+func newAllQueue() *allQueue { return &allQueue{} }
+
+func TestWithAllErrorsCollectsEveryProblem(t *testing.T) {
+	t.Parallel()
+
+	_, err := godi.NewContainer(
+		godi.WithAllErrors(),
+		godi.WithModules(godi.NewModule("jobs", godi.CollectDependencies(
+			godi.NewDependency(newAllQueue),
+			godi.NewDependency(newAllQueue),
+			godi.Decorate(func(s *allService) *allService { return s }),
+		))),
+		godi.WithDependencies(godi.CollectDependencies(
+			godi.NewDependency(newAllCache),
+			godi.NewDependency(newAllCache),
+			godi.NewDependency(newAllCache, godi.WithName("a"), godi.WithGroup("caches")),
+			godi.Decorate(func(s *allService) *allService { return s }),
+		)),
+	)
+	var validationErr *godi.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected *godi.ValidationError, got %T: %v", err, err)
+	}
+
+	kinds := map[godi.WiringErrorKind]int{}
+	for _, collected := range validationErr.Errors {
+		var wiringErr *godi.WiringError
+		if !errors.As(collected, &wiringErr) {
+			t.Fatalf("expected a wiring error, got %v", collected)
+		}
+		kinds[wiringErr.Kind]++
+	}
+	want := map[godi.WiringErrorKind]int{
+		godi.WiringDuplicateProvider: 2,
+		godi.WiringInvalidOptions:    1,
+		godi.WiringUndecoratable:     2,
+	}
+	for kind, count := range want {
+		if kinds[kind] != count {
+			t.Fatalf("expected %d %q errors, got %v:\n%v", count, kind, kinds, err)
+		}
+	}
+
+	message := err.Error()
+	for _, part := range []string{"5 wiring problem(s):", "KIND", "duplicate provider  jobs", "\n5. "} {
+		if !strings.Contains(message, part) {
+			t.Fatalf("expected %q in:\n%s", part, message)
+		}
+	}
+}
+
+func TestValidateAllReportsEveryMissingSlot(t *testing.T) {
+	t.Parallel()
+
+	cnt, err := godi.NewContainer(godi.WithDependencies(godi.CollectDependencies(
+		godi.NewDependency(func(*allCache, *allQueue) *allService { return &allService{} }),
+		godi.NewDependency(func(*allCache) string { return "" }),
+	)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var validationErr *godi.ValidationError
+	if err := cnt.Validate(); err == nil || errors.As(err, &validationErr) {
+		t.Fatalf("expected Validate to stop at the first missing slot, got %v", err)
+	}
+
+	err = cnt.ValidateAll()
+	if !errors.As(err, &validationErr) || len(validationErr.Errors) != 2 {
+		t.Fatalf("expected 2 problems, got %v", err)
+	}
+	var wiringErr *godi.WiringError
+	if !errors.As(validationErr.Errors[0], &wiringErr) || len(wiringErr.Providers) != 2 {
+		t.Fatalf("expected both consumers of *allCache, got %v", validationErr.Errors[0])
+	}
+}
+
+func TestValidateAllPassesValidWiring(t *testing.T) {
+	t.Parallel()
+
+	cnt, err := godi.NewContainer(godi.WithAllErrors(), godi.WithDependencies(godi.CollectDependencies(
+		godi.NewDependency(newAllCache),
+	)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cnt.Validate(); err != nil {
+		t.Fatalf("unexpected validate error: %v", err)
+	}
+}
+
+type (
+	allPing   struct{}
+	allPong   struct{}
+	allWorker struct{}
+)
+
+func TestWithAllErrorsCollectsEveryCategory(t *testing.T) {
+	t.Parallel()
+
+	_, err := godi.NewContainer(godi.WithAllErrors(), godi.WithDependencies(godi.CollectDependencies(
+		godi.NewDependency(newAllCache),
+		godi.NewDependency(newAllCache),
+		godi.NewDependency(newAllQueue, godi.WithName("a"), godi.WithGroup("queues")),
+		godi.NewDependency(func(*allPong) *allPing { return &allPing{} }),
+		godi.NewDependency(func(*allPing) *allPong { return &allPong{} }),
+		godi.NewDependency(func(*allService) *allWorker { return &allWorker{} }),
+	)))
+	var validationErr *godi.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected *godi.ValidationError, got %T: %v", err, err)
+	}
+
+	kinds := map[godi.WiringErrorKind]int{}
+	cycles := 0
+	for _, collected := range validationErr.Errors {
+		var wiringErr *godi.WiringError
+		switch {
+		case errors.As(collected, &wiringErr):
+			kinds[wiringErr.Kind]++
+		case strings.HasPrefix(collected.Error(), "dependency cycle:"):
+			cycles++
+		default:
+			t.Fatalf("unexpected problem: %v", collected)
+		}
+	}
+	want := map[godi.WiringErrorKind]int{
+		godi.WiringDuplicateProvider: 1,
+		godi.WiringInvalidOptions:    1,
+		godi.WiringMissingProvider:   1,
+	}
+	for kind, count := range want {
+		if kinds[kind] != count {
+			t.Fatalf("expected %d %q errors, got %v:\n%v", count, kind, kinds, err)
+		}
+	}
+	if cycles != 1 || len(validationErr.Errors) != 4 {
+		t.Fatalf("expected 4 problems including 1 cycle, got:\n%v", err)
+	}
+}
@@ -0,0 +1,330 @@
+package godi
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WiringErrorKind classifies a WiringError.
+type WiringErrorKind string
+
+// Wiring error kinds reported in WiringError.Kind.
+const (
+	WiringDuplicateProvider WiringErrorKind = "duplicate provider"
+	WiringDuplicateReplace  WiringErrorKind = "duplicate replace"
+	WiringUndecoratable     WiringErrorKind = "undecoratable slot"
+	WiringMissingProvider   WiringErrorKind = "missing provider"
+	WiringInvalidOptions    WiringErrorKind = "invalid options"
+	WiringCheckFailed       WiringErrorKind = "check failed"
+)
+
+// WiringError describes a registration problem found while building or validating a container.
+// Retrieve it with errors.As; module errors keep the "module <name>: " prefix in their message.
+type WiringError struct {
+	Kind WiringErrorKind
+	// Slot labels the affected slot, for example "*app.DB" or "*app.DB[name=primary]".
+	Slot string
+	Type reflect.Type
+	// Module is the scope the problem was found in; empty for the root scope.
+	Module string
+	// Providers lists the involved registrations with their source locations: the conflicting providers,
+	// the decorator without a provider or the consumers of a missing slot.
+	Providers []ProviderInfo
+	// Suggestion names a likely fix, such as a similarly named slot or a type that needs WithMatch.
+	Suggestion string
+	// Err is the underlying error, such as dig's missing type error.
+	Err error
+}
+
+func (e *WiringError) Error() string {
+	var b strings.Builder
+	switch e.Kind {
+	case WiringDuplicateProvider, WiringDuplicateReplace:
+		b.WriteString(string(e.Kind) + " for slot " + e.Slot)
+	case WiringUndecoratable:
+		b.WriteString("cannot decorate slot " + e.Slot + ": no provider")
+	case WiringMissingProvider:
+		b.WriteString("missing provider for slot " + e.Slot)
+	case WiringCheckFailed:
+		b.WriteString("check failed for slot " + e.Slot + ": " + e.Err.Error())
+	default:
+		b.WriteString(e.Err.Error())
+	}
+
+	if len(e.Providers) > 0 {
+		labels := make([]string, 0, len(e.Providers))
+		for _, provider := range e.Providers {
+			labels = append(labels, providerInfoLabel(provider))
+		}
+		b.WriteString(" (" + strings.Join(labels, ", ") + ")")
+	}
+	if e.Suggestion != "" {
+		b.WriteString("; did you mean " + e.Suggestion + "?")
+	}
+	if e.Kind == WiringMissingProvider && e.Err != nil {
+		b.WriteString(": " + e.Err.Error())
+	}
+	return b.String()
+}
+
+func (e *WiringError) Unwrap() error {
+	return e.Err
+}
+
+func providerInfoLabel(info ProviderInfo) string {
+	label := info.Type
+	if info.Constructor != "" {
+		label = info.Constructor
+	}
+	if info.Module != "" {
+		label = info.Module + "/" + label
+	}
+	if info.File != "" && info.Line > 0 {
+		label = fmt.Sprintf("%s at %s:%d", label, info.File, info.Line)
+	}
+	return label
+}
+
+func newSlotError(kind WiringErrorKind, slot slotKey, entries ...depEntry) *WiringError {
+	err := &WiringError{Kind: kind, Slot: slotLabel(slot), Type: slot.t}
+	for _, entry := range entries {
+		err.Providers = append(err.Providers, describeEntry(entry))
+		err.Module = entry.module
+	}
+	return err
+}
+
+func newOptionsError(entry depEntry, cause error) *WiringError {
+	err := &WiringError{Kind: WiringInvalidOptions, Module: entry.module, Err: cause}
+	if t := entry.dep.Type(); t != nil {
+		err.Type = t
+		err.Slot = t.String()
+	}
+	err.Providers = []ProviderInfo{describeEntry(entry)}
+	return err
+}
+
+// checkErrors runs the WithCheck checks of a provider.
+func checkErrors(entry depEntry) []error {
+	var errs []error
+	for _, check := range entry.dep.checks {
+		if err := check(); err != nil {
+			wiringErr := newOptionsError(entry, err)
+			wiringErr.Kind = WiringCheckFailed
+			errs = append(errs, wiringErr)
+		}
+	}
+	return errs
+}
+
+// missingError wraps a validation failure of provider caused by a slot without a provider into a WiringError
+// naming the slot and its consumers. dig reports the innermost constructor that lacks a slot, which may be a
+// transitive dependency of provider, so the error is matched to the missing edge of that constructor and
+// type. Other failures are returned unchanged.
+func (c *Container) missingError(built *buildResult, scope string, provider depEntry, cause error) error {
+	failure, ok := parseMissingFailure(cause)
+	if !ok {
+		return cause
+	}
+	edges := c.missingEdges()
+	var match *missingEdge
+	for i, edge := range edges {
+		if !failure.missing(edge) {
+			continue
+		}
+		// A constructor wrapped by godi (such as a family member) is reported by dig under the wrapper's name,
+		// so the edge of the validated provider itself matches by identity.
+		isProvider := edge.Scope == scope && edge.consumer.ID == provider.id && edge.consumer.Module == provider.module
+		if !isProvider && !failure.reports(edge.consumer) {
+			continue
+		}
+		if match == nil || (edge.Scope == scope && match.Scope != scope) {
+			match = &edges[i]
+		}
+	}
+	if match == nil {
+		return cause
+	}
+	return newMissingError(*match, edges, built.globalResolution, built.moduleResolutions, cause)
+}
+
+// missingFailure is the innermost "missing dependencies for function" failure of a dig error.
+type missingFailure struct {
+	function string
+	file     string
+	line     int
+	// types holds dig's slot keys, such as "*app.DB" or `*app.DB[name="primary"]`.
+	types []string
+}
+
+func parseMissingFailure(err error) (missingFailure, bool) {
+	const prefix = "missing dependencies for function "
+	msg := err.Error()
+	i := strings.LastIndex(msg, prefix)
+	if i < 0 {
+		return missingFailure{}, false
+	}
+	function, rest, ok := strings.Cut(msg[i+len(prefix):], " (")
+	if !ok {
+		return missingFailure{}, false
+	}
+	location, rest, ok := strings.Cut(rest, "): ")
+	if !ok {
+		return missingFailure{}, false
+	}
+
+	// dig quotes the package path: "example.com/app".NewDB.
+	failure := missingFailure{function: strings.Replace(strings.TrimPrefix(function, `"`), `".`, ".", 1)}
+	if colon := strings.LastIndex(location, ":"); colon > 0 {
+		failure.file = location[:colon]
+		failure.line, _ = strconv.Atoi(location[colon+1:])
+	}
+	for _, label := range []string{"missing type: ", "missing types: "} {
+		if list, found := strings.CutPrefix(rest, label); found {
+			for _, item := range strings.Split(list, "; ") {
+				item, _, _ = strings.Cut(item, " (did you mean")
+				failure.types = append(failure.types, item)
+			}
+		}
+	}
+	return failure, len(failure.types) > 0
+}
+
+// missing reports whether the slot of edge is one of the types dig found missing.
+func (f missingFailure) missing(edge missingEdge) bool {
+	key := edge.Type.String()
+	if edge.Name != "" {
+		key += fmt.Sprintf("[name=%q]", edge.Name)
+	}
+	return slices.Contains(f.types, key)
+}
+
+// reports reports whether node is the constructor dig found lacking a slot.
+func (f missingFailure) reports(node ProviderNode) bool {
+	if node.File != "" && node.File == f.file && node.Line == f.line {
+		return true
+	}
+	return node.Constructor != "" && node.Constructor == f.function
+}
+
+// newMissingError describes the slot of missing with every consumer that requires it in the same scope.
+func newMissingError(
+	missing missingEdge,
+	edges []missingEdge,
+	global resolvedScope,
+	modules map[string]resolvedScope,
+	cause error,
+) *WiringError {
+	slot := slotKey{t: missing.Type, name: missing.Name}
+	available := global.slots
+	err := &WiringError{Kind: WiringMissingProvider, Slot: slotLabel(slot), Type: missing.Type, Err: cause}
+	if missing.Scope != rootScopeName {
+		err.Module = missing.Scope
+		available = mergeSlots(modules[missing.Scope].slots, available)
+	}
+	for _, edge := range edges {
+		if edge.Scope == missing.Scope && edge.Type == missing.Type && edge.Name == missing.Name {
+			err.Providers = append(err.Providers, nodeProviderInfo(edge.consumer))
+		}
+	}
+	err.Suggestion = suggestSlot(slot, available)
+	return err
+}
+
+func nodeProviderInfo(node ProviderNode) ProviderInfo {
+	return ProviderInfo{
+		Module:      node.Module,
+		Constructor: node.Constructor,
+		File:        node.File,
+		Line:        node.Line,
+		Type:        node.Type,
+		Name:        node.Name,
+		Group:       node.Group,
+	}
+}
+
+// suggestSlot looks for the slot a registration most likely meant: the same type under another name,
+// the pointer or element type, a provided type implementing a wanted interface without WithMatch, or a
+// type with the same name from another package or a near-identical spelling. Spellings are compared on the
+// type names alone and the allowed distance grows with the name, so short names must match exactly.
+func suggestSlot(want slotKey, available map[slotKey]depEntry) string {
+	candidates := make([]slotKey, 0, len(available))
+	for slot := range available {
+		if slot.group == "" && slot.t != nil && slot != want {
+			candidates = append(candidates, slot)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return slotLabel(candidates[i]) < slotLabel(candidates[j]) })
+
+	for _, slot := range candidates {
+		if slot.t == want.t && slot.name != want.name {
+			return slotLabel(slot)
+		}
+	}
+	for _, slot := range candidates {
+		if slot.name == want.name && (slot.t == reflect.PointerTo(want.t) ||
+			(want.t.Kind() == reflect.Pointer && slot.t == want.t.Elem())) {
+			return slotLabel(slot)
+		}
+	}
+	if want.t.Kind() == reflect.Interface {
+		for _, slot := range candidates {
+			if slot.name == want.name && slot.t.Kind() != reflect.Interface && slot.t.Implements(want.t) {
+				return fmt.Sprintf("%s with godi.WithMatch(new(%s))", slotLabel(slot), want.t)
+			}
+		}
+	}
+	wantBase := baseType(want.t)
+	if wantBase.Name() == "" {
+		return ""
+	}
+	for _, slot := range candidates {
+		base := baseType(slot.t)
+		if base.Name() == "" || slot.name != want.name {
+			continue
+		}
+		// Implementations of a wanted interface were offered above; any other concrete type cannot satisfy it
+		// however close its name is.
+		if want.t.Kind() == reflect.Interface && slot.t.Kind() != reflect.Interface {
+			continue
+		}
+		sameName := base.Name() == wantBase.Name() && base.PkgPath() != wantBase.PkgPath()
+		if sameName || editDistance(base.Name(), wantBase.Name()) <= len(wantBase.Name())/3 {
+			return slotLabel(slot)
+		}
+	}
+	return ""
+}
+
+// baseType strips pointers from t so spellings are compared on the declared type.
+func baseType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,266 @@
+package godi_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/dig"
+
+	"github.com/assurrussa/godi"
+)
+
+type (
+	wiringStore interface{ Get() string }
+	wiringDB    struct{}
+	wiringRepo  struct{}
+)
+
+func (*wiringDB) Get() string { return "" }
+
+func newWiringDB() *wiringDB { return &wiringDB{} }
+
+func newWiringDBAgain() *wiringDB { return &wiringDB{} }
+
+func wiringErrorOf(t *testing.T, err error) *godi.WiringError {
+	t.Helper()
+	var wiringErr *godi.WiringError
+	if !errors.As(err, &wiringErr) {
+		t.Fatalf("expected *godi.WiringError, got %T: %v", err, err)
+	}
+	return wiringErr
+}
+
+func TestWiringErrorDuplicateProvider(t *testing.T) {
+	t.Parallel()
+
+	_, err := godi.NewContainer(godi.WithDependencies(godi.CollectDependencies(
+		godi.NewDependency(newWiringDB),
+		godi.NewDependency(newWiringDBAgain),
+	)))
+	wiringErr := wiringErrorOf(t, err)
+	if wiringErr.Kind != godi.WiringDuplicateProvider || wiringErr.Slot != "*godi_test.wiringDB" {
+		t.Fatalf("unexpected error: %+v", wiringErr)
+	}
+	if len(wiringErr.Providers) != 2 || wiringErr.Providers[1].Line == 0 ||
+		!strings.HasSuffix(wiringErr.Providers[1].File, "wiring_error_test.go") {
+		t.Fatalf("unexpected providers: %+v", wiringErr.Providers)
+	}
+	if wiringErr.Suggestion != "godi.Replace" || !strings.Contains(err.Error(), "did you mean godi.Replace?") {
+		t.Fatalf("unexpected message: %v", err)
+	}
+}
+
+func TestWiringErrorUndecoratableSuggestsName(t *testing.T) {
+	t.Parallel()
+
+	_, err := godi.NewContainer(godi.WithModules(godi.NewModule("storage", godi.CollectDependencies(
+		godi.NewDependency(newWiringDB, godi.WithName("primary")),
+		godi.Decorate(func(db *wiringDB) *wiringDB { return db }),
+	))))
+	wiringErr := wiringErrorOf(t, err)
+	if wiringErr.Kind != godi.WiringUndecoratable || wiringErr.Module != "storage" {
+		t.Fatalf("unexpected error: %+v", wiringErr)
+	}
+	if wiringErr.Suggestion != "*godi_test.wiringDB[name=primary]" {
+		t.Fatalf("unexpected suggestion: %q", wiringErr.Suggestion)
+	}
+	if !strings.HasPrefix(err.Error(), "module storage: cannot decorate slot *godi_test.wiringDB: no provider") {
+		t.Fatalf("unexpected message: %v", err)
+	}
+}
+
+func TestWiringErrorMissingSuggestsMatching(t *testing.T) {
+	t.Parallel()
+
+	cnt, err := godi.NewContainer(godi.WithDependencies(godi.CollectDependencies(
+		godi.NewDependency(newWiringDB),
+		godi.NewDependency(func(wiringStore) *wiringRepo { return &wiringRepo{} }),
+	)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = cnt.Validate()
+	wiringErr := wiringErrorOf(t, err)
+	if wiringErr.Kind != godi.WiringMissingProvider || wiringErr.Slot != "godi_test.wiringStore" {
+		t.Fatalf("unexpected error: %+v", wiringErr)
+	}
+	if len(wiringErr.Providers) != 1 || wiringErr.Providers[0].Line == 0 {
+		t.Fatalf("unexpected consumers: %+v", wiringErr.Providers)
+	}
+	want := "*godi_test.wiringDB with godi.WithMatch(new(godi_test.wiringStore))"
+	if wiringErr.Suggestion != want {
+		t.Fatalf("unexpected suggestion: %q", wiringErr.Suggestion)
+	}
+	if wiringErr.Unwrap() == nil || !strings.Contains(err.Error(), "missing type") {
+		t.Fatalf("expected the dig error to be kept: %v", err)
+	}
+}
+
+func TestWiringErrorInvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	_, err := godi.NewContainer(godi.WithDependencies(godi.CollectDependencies(
+		godi.NewDependency(newWiringDB, godi.WithName("a"), godi.WithGroup("dbs")),
+	)))
+	wiringErr := wiringErrorOf(t, err)
+	if wiringErr.Kind != godi.WiringInvalidOptions || len(wiringErr.Providers) != 1 {
+		t.Fatalf("unexpected error: %+v", wiringErr)
+	}
+	if !strings.HasPrefix(err.Error(), "invalid dependency options: WithName cannot be used with WithGroup") {
+		t.Fatalf("unexpected message: %v", err)
+	}
+}
+
+func TestWithCheckRunsForWinningProviders(t *testing.T) {
+	t.Parallel()
+
+	failing := godi.WithCheck(func() error { return errors.New("not configured") })
+	cnt, err := godi.NewContainer(godi.WithDependencies(godi.CollectDependencies(
+		godi.NewDependency(newWiringDB, failing),
+		godi.NewDependency(func() *wiringRepo { return &wiringRepo{} }, failing),
+		godi.Replace(newWiringDBAgain),
+	)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = cnt.Validate()
+	wiringErr := wiringErrorOf(t, err)
+	if wiringErr.Kind != godi.WiringCheckFailed || wiringErr.Slot != "*godi_test.wiringRepo" {
+		t.Fatalf("expected only the repo check to fail, got %v", err)
+	}
+	if !strings.HasPrefix(err.Error(), "check failed for slot *godi_test.wiringRepo: not configured") {
+		t.Fatalf("unexpected message: %v", err)
+	}
+}
+
+type (
+	wiringCache  struct{}
+	wiringConfig struct{}
+	wiringClient struct{}
+	wiringQueue  struct{}
+	wiringInner  struct {
+		dig.In
+		Queue *wiringQueue
+	}
+)
+
+func newWiringRepo(*wiringCache) *wiringRepo { return &wiringRepo{} }
+
+func newWiringCache(*wiringConfig) *wiringCache { return &wiringCache{} }
+
+func newWiringClient(*wiringDB) *wiringClient { return &wiringClient{} }
+
+func TestWiringErrorMissingMatchesFailingConstructor(t *testing.T) {
+	t.Parallel()
+
+	// The repo is validated first and fails through the cache, which lacks the config; the client's missing
+	// DB comes first among the missing edges but is unrelated to that failure.
+	cnt, err := godi.NewContainer(godi.WithDependencies(godi.CollectDependencies(
+		godi.NewDependency(newWiringRepo),
+		godi.NewDependency(newWiringClient),
+		godi.NewDependency(newWiringCache),
+	)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = cnt.Validate()
+	wiringErr := wiringErrorOf(t, err)
+	if wiringErr.Kind != godi.WiringMissingProvider || wiringErr.Slot != "*godi_test.wiringConfig" {
+		t.Fatalf("expected the cache's missing config, got %v", err)
+	}
+	if len(wiringErr.Providers) != 1 || !strings.HasSuffix(wiringErr.Providers[0].Constructor, "newWiringCache") {
+		t.Fatalf("expected the cache as consumer, got %+v", wiringErr.Providers)
+	}
+}
+
+func TestWiringErrorMissingKeepsUnmatchedFailure(t *testing.T) {
+	t.Parallel()
+
+	// The graph sees the nested parameter object as one slot, so dig's missing queue matches no edge, and
+	// the client's missing DB must not be blamed for it.
+	cnt, err := godi.NewContainer(godi.WithDependencies(godi.CollectDependencies(
+		godi.NewDependency(func(struct {
+			dig.In
+			Inner wiringInner
+		},
+		) *wiringRepo {
+			return &wiringRepo{}
+		}),
+		godi.NewDependency(newWiringClient),
+	)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = cnt.Validate()
+	var wiringErr *godi.WiringError
+	if errors.As(err, &wiringErr) {
+		t.Fatalf("expected the dig error unchanged, got %v", err)
+	}
+	if err == nil || !strings.Contains(err.Error(), "missing type: *godi_test.wiringQueue") {
+		t.Fatalf("expected the missing queue, got %v", err)
+	}
+}
+
+type (
+	wiringConfg struct{}
+)
+
+func TestWiringErrorSuggestsOnlyCloseTypeNames(t *testing.T) {
+	t.Parallel()
+
+	// Short local names, as in a pg package, where any edit is a large part of the name.
+	type (
+		I  interface{ Run() }
+		A  struct{}
+		DB struct{}
+		Tx struct{}
+	)
+	tests := []struct {
+		name     string
+		provide  any
+		consume  any
+		slot     string
+		expected string
+	}{
+		{
+			name:    "concrete type for an interface it does not implement",
+			provide: func() *A { return &A{} },
+			consume: func(I) *wiringRepo { return &wiringRepo{} },
+			slot:    "godi_test.I",
+		},
+		{
+			name:    "short names two edits apart",
+			provide: func() *DB { return &DB{} },
+			consume: func(*Tx) *wiringRepo { return &wiringRepo{} },
+			slot:    "*godi_test.Tx",
+		},
+		{
+			name:     "misspelled name",
+			provide:  func() *wiringConfg { return &wiringConfg{} },
+			consume:  func(*wiringConfig) *wiringRepo { return &wiringRepo{} },
+			slot:     "*godi_test.wiringConfig",
+			expected: "*godi_test.wiringConfg",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cnt, err := godi.NewContainer(godi.WithDependencies(godi.CollectDependencies(
+				godi.NewDependency(tt.provide),
+				godi.NewDependency(tt.consume),
+			)))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			wiringErr := wiringErrorOf(t, cnt.Validate())
+			if wiringErr.Slot != tt.slot || wiringErr.Suggestion != tt.expected {
+				t.Fatalf("unexpected suggestion for %s: %q", wiringErr.Slot, wiringErr.Suggestion)
+			}
+		})
+	}
+}